@@ -0,0 +1,28 @@
+/*
+File: convert.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package main
+
+import (
+	"flag"
+	"io"
+)
+
+func runConvert(args []string, stdout io.Writer) error {
+	var (
+		fs   = flag.NewFlagSet("convert", flag.ContinueOnError)
+		from = fs.String("from", "", "input format (default from the input file extension, or json)")
+		to   = fs.String("to", "", "output format (default from the output file extension, or json)")
+		out  = fs.String("o", "", "output file")
+	)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+	var h, err = readGrid(fs.Arg(0), *from)
+	if err != nil {
+		return err
+	}
+	return writeGrid(h, *out, *to, stdout)
+}
@@ -0,0 +1,49 @@
+/*
+File: create.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package main
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"encoding/json"
+	"flag"
+	"io"
+)
+
+func runCreate(args []string, stdout io.Writer) error {
+	var (
+		fs      = flag.NewFlagSet("create", flag.ContinueOnError)
+		n       = fs.Int("cols", 7, "number of columns")
+		m       = fs.Int("rows", 7, "number of rows")
+		r       = fs.Float64("radius", 1, "tile radius (apothem)")
+		wrap    = fs.String("wrap", "none", "wrapping borders: none, horizontal, vertical or both")
+		compact = fs.Bool("compact", false, "create a compact grid")
+		fill    = fs.String("fill", "", "initial value of every tile, as JSON or a plain string")
+		out     = fs.String("o", "", "output file")
+		to      = fs.String("f", "", "output format (default from the output file extension, or json)")
+	)
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	var h, err = newGrid(*n, *m, *r, *wrap, *compact)
+	if err != nil {
+		return err
+	}
+	if *fill != "" {
+		var value = parseValue(*fill)
+		eachTile(h, func(c hexcoords.Hex) { h.SetTileValue(c, value) })
+	}
+	return writeGrid(h, *out, *to, stdout)
+}
+
+//  The value of s as JSON, or s itself if it is not valid JSON.
+func parseValue(s string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return s
+	}
+	return value
+}
@@ -0,0 +1,336 @@
+/*
+File: files.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package main
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/mapfile"
+	"github.com/bmatsuo/hexgrid/tiled"
+
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var errUsage = errors.New("usage")
+
+//  A file format grids can be read from or written to. Either function may
+//  be nil.
+type format struct {
+	name  string
+	exts  []string
+	read  func(r io.Reader) (*hexgrid.Grid, error)
+	write func(w io.Writer, h *hexgrid.Grid) error
+}
+
+var formats = map[string]*format{
+	"json": {"json", []string{".json"}, readJSON, writeJSON},
+	"map":  {"map", []string{".map"}, readMap, writeMap},
+	"tmx":  {"tmx", []string{".tmx"}, readTiled(tiled.ReadTMX), writeTiled((*tiled.Map).WriteTMX)},
+	"tmj":  {"tmj", []string{".tmj"}, readTiled(tiled.ReadTMJ), writeTiled((*tiled.Map).WriteTMJ)},
+}
+
+func formatNames() string {
+	var names []string
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+//  The format called name, or if name is empty the format for the extension
+//  of path, or if that is unknown the format called fallback.
+func findFormat(name, path, fallback string) (*format, error) {
+	if name == "" {
+		var ext = strings.ToLower(filepath.Ext(path))
+		for _, f := range formats {
+			for _, e := range f.exts {
+				if e == ext {
+					return f, nil
+				}
+			}
+		}
+		name = fallback
+	}
+	if f, ok := formats[name]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (formats: %s)", name, formatNames())
+}
+
+//  Read a grid from path, or from standard input if path is "" or "-".
+func readGrid(path, formatName string) (*hexgrid.Grid, error) {
+	var f, err = findFormat(formatName, path, "json")
+	if err != nil {
+		return nil, err
+	}
+	if f.read == nil {
+		return nil, fmt.Errorf("cannot read %s files", f.name)
+	}
+	var r io.Reader = os.Stdin
+	if path != "" && path != "-" {
+		var file, err = os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		r = file
+	}
+	return f.read(r)
+}
+
+//  Write data produced by write to path, or to stdout if path is "" or "-".
+func writeOutput(path string, stdout io.Writer, write func(io.Writer) error) error {
+	if path == "" || path == "-" {
+		return write(stdout)
+	}
+	var file, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+//  Write h to path in the format called formatName, or the format for the
+//  extension of path.
+func writeGrid(h *hexgrid.Grid, path, formatName string, stdout io.Writer) error {
+	var f, err = findFormat(formatName, path, "json")
+	if err != nil {
+		return err
+	}
+	if f.write == nil {
+		return fmt.Errorf("cannot write %s files", f.name)
+	}
+	return writeOutput(path, stdout, func(w io.Writer) error { return f.write(w, h) })
+}
+
+//  The JSON document of a saved grid. Vertices and edges are keyed by their
+//  canonical coordinates.
+type gridDocument struct {
+	Columns  int                              `json:"columns"`
+	Rows     int                              `json:"rows"`
+	Radius   float64                          `json:"radius"`
+	Wrap     string                           `json:"wrap,omitempty"`
+	Compact  bool                             `json:"compact,omitempty"`
+	Tiles    map[hexcoords.Hex]interface{}    `json:"tiles,omitempty"`
+	Vertices map[hexcoords.Vertex]interface{} `json:"vertices,omitempty"`
+	Edges    map[hexcoords.Edge]interface{}   `json:"edges,omitempty"`
+}
+
+var topologyNames = map[string]hexgrid.Topology{
+	"":           hexgrid.Bounded,
+	"none":       hexgrid.Bounded,
+	"horizontal": hexgrid.WrapHorizontal,
+	"vertical":   hexgrid.WrapVertical,
+	"both":       hexgrid.WrapBoth,
+}
+
+func topologyName(t hexgrid.Topology) string {
+	for name, topology := range topologyNames {
+		if name != "" && name != "none" && topology == t {
+			return name
+		}
+	}
+	return ""
+}
+
+//  Create an empty grid after validating its dimensions, which NewGrid
+//  would otherwise panic on.
+func newGrid(n, m int, r float64, wrap string, compact bool) (*hexgrid.Grid, error) {
+	var topology, ok = topologyNames[wrap]
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("unknown wrap %q (none, horizontal, vertical or both)", wrap)
+	case n <= 0 || m <= 0:
+		return nil, fmt.Errorf("invalid size %dx%d", n, m)
+	case r <= 0:
+		return nil, fmt.Errorf("invalid radius %v", r)
+	case topology == hexgrid.Bounded && (n%2 == 0 || m%2 == 0):
+		return nil, fmt.Errorf("a grid without wrapping needs odd dimensions, not %dx%d", n, m)
+	case topology&hexgrid.WrapHorizontal != 0 && n%2 == 1:
+		return nil, fmt.Errorf("a grid wrapping horizontally needs an even number of columns, not %d", n)
+	}
+	if compact {
+		return hexgrid.NewCompactWrappedGrid(n, m, r, topology, nil, nil, nil), nil
+	}
+	return hexgrid.NewWrappedGrid(n, m, r, topology, nil, nil, nil), nil
+}
+
+func readJSON(r io.Reader) (*hexgrid.Grid, error) {
+	var data, err = ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var doc gridDocument
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	h, err := newGrid(doc.Columns, doc.Rows, doc.Radius, doc.Wrap, doc.Compact)
+	if err != nil {
+		return nil, err
+	}
+	for c, value := range doc.Tiles {
+		if h.GetTile(c) == nil {
+			return nil, fmt.Errorf("tile %v is outside the grid", c)
+		}
+		h.SetTileValue(c, value)
+	}
+	for vc, value := range doc.Vertices {
+		if h.GetVertex(vc) == nil {
+			return nil, fmt.Errorf("vertex %v is outside the grid", vc)
+		}
+		h.SetVertexValue(vc, value)
+	}
+	for e, value := range doc.Edges {
+		if h.GetEdge(e) == nil {
+			return nil, fmt.Errorf("edge %v is outside the grid", e)
+		}
+		h.SetEdgeValue(e, value)
+	}
+	return h, nil
+}
+
+func writeJSON(w io.Writer, h *hexgrid.Grid) error {
+	var doc = gridDocument{
+		Columns:  h.NumCols(),
+		Rows:     h.NumRows(),
+		Radius:   h.Radius(),
+		Wrap:     topologyName(h.Topology()),
+		Compact:  h.IsCompact(),
+		Tiles:    make(map[hexcoords.Hex]interface{}),
+		Vertices: make(map[hexcoords.Vertex]interface{}),
+		Edges:    make(map[hexcoords.Edge]interface{}),
+	}
+	eachTile(h, func(c hexcoords.Hex) {
+		if value := h.TileValue(c); value != nil {
+			doc.Tiles[c] = value
+		}
+		for _, vc := range c.Vertices(hex.NilDirection) {
+			if value := h.VertexValue(vc); value != nil {
+				var canon, _ = h.CanonicalVertex(vc)
+				doc.Vertices[canon] = value
+			}
+		}
+		for _, e := range c.Edges(hex.NilDirection) {
+			if value := h.EdgeValue(e); value != nil {
+				var canon, _ = h.CanonicalEdge(e)
+				doc.Edges[canon] = value
+			}
+		}
+	})
+	var data, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+//  Map files (see package mapfile) hold tile types and annotations as
+//  strings.
+func readMap(r io.Reader) (*hexgrid.Grid, error) {
+	var m, err = mapfile.Read(r)
+	if err != nil {
+		return nil, err
+	}
+	return m.Grid(), nil
+}
+
+func writeMap(w io.Writer, h *hexgrid.Grid) error {
+	var m, err = mapfile.FromGrid(h, nil)
+	if err != nil {
+		return err
+	}
+	return m.Write(w)
+}
+
+//  Tiled maps (see package tiled) are read from their first tile layer.
+//  Tile values are GIDs.
+func readTiled(read func(io.Reader) (*tiled.Map, error)) func(io.Reader) (*hexgrid.Grid, error) {
+	return func(r io.Reader) (*hexgrid.Grid, error) {
+		var m, err = read(r)
+		if err != nil {
+			return nil, err
+		}
+		return m.Grid(0)
+	}
+}
+
+//  Tile values written to Tiled maps must be GIDs, or nil. Grids with small
+//  radii, not measured in pixels, are scaled to tiles 32 pixels high.
+func writeTiled(write func(*tiled.Map, io.Writer) error) func(io.Writer, *hexgrid.Grid) error {
+	return func(w io.Writer, h *hexgrid.Grid) error {
+		var (
+			scale = 1.0
+			err   error
+		)
+		if h.Radius() < 8 {
+			scale = 16 / h.Radius()
+		}
+		var m = tiled.FromGrid(h, "tiles", scale, func(t *hexgrid.Tile) uint32 {
+			var gid, ok = tileGID(t.Value)
+			if !ok && err == nil {
+				err = fmt.Errorf("tile %v value %v is not a tile GID", t.Hex, t.Value)
+			}
+			return gid
+		})
+		if err != nil {
+			return err
+		}
+		return write(m, w)
+	}
+}
+
+func tileGID(value hexgrid.Value) (uint32, bool) {
+	switch v := value.(type) {
+	case nil:
+		return 0, true
+	case uint32:
+		return v, true
+	case int:
+		return uint32(v), v >= 0 && int64(v) <= math.MaxUint32
+	case float64:
+		return uint32(v), v >= 0 && v <= math.MaxUint32 && v == math.Floor(v)
+	}
+	return 0, false
+}
+
+//  Call fn with each tile of h.
+func eachTile(h *hexgrid.Grid, fn func(hexcoords.Hex)) {
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			fn(hexcoords.Hex{u, v})
+		}
+	}
+}
+
+//  Parse the flags of a command, which has at most maxArgs arguments.
+func parseFlags(fs *flag.FlagSet, args []string, maxArgs int) error {
+	fs.SetOutput(os.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() > maxArgs {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
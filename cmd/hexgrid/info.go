@@ -0,0 +1,210 @@
+/*
+File: info.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package main
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var directionNames = map[hex.Direction]string{
+	hex.N: "N", hex.NE: "NE", hex.E: "E", hex.SE: "SE",
+	hex.S: "S", hex.SW: "SW", hex.W: "W", hex.NW: "NW",
+}
+
+func runInfo(args []string, stdout io.Writer) error {
+	var (
+		fs   = flag.NewFlagSet("info", flag.ContinueOnError)
+		path = fs.String("grid", "", "describe the coordinate within a saved grid")
+		from = fs.String("from", "", "grid file format (default from the file extension, or json)")
+	)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	var h *hexgrid.Grid
+	if *path != "" {
+		var err error
+		if h, err = readGrid(*path, *from); err != nil {
+			return err
+		}
+	}
+	var s = fs.Arg(0)
+	if e, err := hexcoords.ParseEdge(s); err == nil {
+		return edgeInfo(stdout, h, e)
+	}
+	if vc, err := hexcoords.ParseVertex(s); err == nil {
+		return vertexInfo(stdout, h, vc)
+	}
+	if c, err := hexcoords.ParseHex(s); err == nil {
+		return tileInfo(stdout, h, c)
+	}
+	if h != nil {
+		if c, err := h.ParseA1(s); err == nil {
+			return tileInfo(stdout, h, c)
+		}
+	}
+	return fmt.Errorf("invalid coordinate %q", s)
+}
+
+//  Writes labeled lines of coordinates.
+type infoWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (iw *infoWriter) line(label string, items ...interface{}) {
+	if iw.err != nil {
+		return
+	}
+	var strs = make([]string, len(items))
+	for i, item := range items {
+		strs[i] = fmt.Sprint(item)
+	}
+	_, iw.err = fmt.Fprintf(iw.w, "%-10s %s\n", label+":", strings.Join(strs, " "))
+}
+
+//  Tiles in cs that are part of h, wrapped into its bounds. Returns cs if h
+//  is nil.
+func gridTiles(h *hexgrid.Grid, cs []hexcoords.Hex) []interface{} {
+	var items []interface{}
+	for _, c := range cs {
+		if h != nil {
+			var ok bool
+			if c, ok = h.Wrap(c); !ok {
+				continue
+			}
+		}
+		items = append(items, c)
+	}
+	return items
+}
+
+func tileInfo(w io.Writer, h *hexgrid.Grid, c hexcoords.Hex) error {
+	var iw = &infoWriter{w: w}
+	if h != nil {
+		var ok bool
+		if c, ok = h.Wrap(c); !ok {
+			return fmt.Errorf("tile %v is outside the grid", c)
+		}
+	}
+	iw.line("tile", c)
+	if h != nil {
+		iw.line("name", h.A1(c))
+		iw.line("value", valueString(h.TileValue(c)))
+	}
+	var adjs []interface{}
+	for _, dir := range hex.EdgeDirections() {
+		var adj = c.Adjacent(dir)
+		if h != nil {
+			var ok bool
+			if adj, ok = h.Wrap(adj); !ok {
+				continue
+			}
+		}
+		adjs = append(adjs, directionNames[dir]+"="+adj.String())
+	}
+	iw.line("adjacent", adjs...)
+	var vertices, edges []interface{}
+	for _, vc := range c.Vertices(hex.NilDirection) {
+		vertices = append(vertices, vc)
+	}
+	for _, e := range c.Edges(hex.NilDirection) {
+		edges = append(edges, e)
+	}
+	iw.line("vertices", vertices...)
+	iw.line("edges", edges...)
+	return iw.err
+}
+
+func vertexInfo(w io.Writer, h *hexgrid.Grid, vc hexcoords.Vertex) error {
+	var (
+		iw    = &infoWriter{w: w}
+		canon = vc.Canonical()
+	)
+	if h != nil {
+		var ok bool
+		if canon, ok = h.CanonicalVertex(vc); !ok {
+			return fmt.Errorf("vertex %v is outside the grid", vc)
+		}
+	}
+	iw.line("vertex", vc)
+	iw.line("canonical", canon)
+	if h != nil {
+		iw.line("value", valueString(h.VertexValue(vc)))
+	}
+	var identical []interface{}
+	for _, ident := range vc.IdenticalVertices() {
+		if h == nil || h.GetTile(ident.Hex()) != nil {
+			identical = append(identical, ident)
+		}
+	}
+	iw.line("identical", identical...)
+	iw.line("tiles", gridTiles(h, vc.Incidents())...)
+	// Each identical vertex is the origin of one of the edges meeting at
+	// the vertex, running clockwise around its tile.
+	var edges, adjacent []interface{}
+	for _, ident := range vc.IdenticalVertices() {
+		var (
+			k   = hex.VertexIndexClockwise(ident.K)
+			e   = hexcoords.Edge{ident.U, ident.V, ident.K, k}
+			adj = hexcoords.Vertex{ident.U, ident.V, k}
+		)
+		if h == nil || h.GetEdge(e) != nil {
+			edges = append(edges, e)
+			adjacent = append(adjacent, adj)
+		}
+	}
+	iw.line("edges", edges...)
+	iw.line("adjacent", adjacent...)
+	return iw.err
+}
+
+func edgeInfo(w io.Writer, h *hexgrid.Grid, e hexcoords.Edge) error {
+	if e.Direction() == hex.NilDirection {
+		return fmt.Errorf("%v is not a side of its tile", e)
+	}
+	var (
+		iw    = &infoWriter{w: w}
+		canon = e.Canonical()
+	)
+	if h != nil {
+		var ok bool
+		if canon, ok = h.CanonicalEdge(e); !ok {
+			return fmt.Errorf("edge %v is outside the grid", e)
+		}
+	}
+	iw.line("edge", e)
+	iw.line("canonical", canon)
+	if h != nil {
+		iw.line("value", valueString(h.EdgeValue(e)))
+	}
+	var identical []interface{}
+	for _, c := range e.Incidents() {
+		if h != nil && h.GetTile(c) == nil {
+			continue
+		}
+		for _, side := range c.Edges(hex.NilDirection) {
+			if side.IsIdentical(e) {
+				identical = append(identical, side)
+			}
+		}
+	}
+	iw.line("identical", identical...)
+	iw.line("tiles", gridTiles(h, e.Incidents())...)
+	var v1, v2 = e.Ends()
+	iw.line("ends", v1, v2)
+	return iw.err
+}
@@ -0,0 +1,85 @@
+/*
+File: main.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+/*
+Command hexgrid creates, inspects, renders and converts hexagonal grids.
+
+Usage:
+
+	hexgrid create [-cols n] [-rows m] [-radius r] [-wrap topology] [-compact] [-fill value] [-o file]
+	hexgrid info [-grid file] coordinate
+	hexgrid render [-f ascii|svg|png] [-label none|coords|a1|value] [-scale s] [-o file] [file]
+	hexgrid convert [-from format] [-to format] [-o file] [file]
+
+Grids are saved as JSON documents (format "json", extension .json) holding
+the dimensions of the grid and every tile, vertex and edge value that is not
+null. Grids can also be read from and written to map files (format "map",
+extension .map, see package mapfile) and Tiled maps (formats "tmx" and
+"tmj", see package tiled), whose tile values are tile GIDs. The format of a
+file is chosen by its extension unless given explicitly. A missing file or
+"-" means standard input or output.
+
+Coordinates are written as for hexcoords: "(3,-2)" is a tile, "(3,-2)#4"
+a vertex and "(3,-2)#4-5" an edge. With -grid, info also accepts
+spreadsheet-style tile names like "C7".
+*/
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"create", "create a grid", runCreate},
+		{"info", "describe a tile, vertex or edge", runInfo},
+		{"render", "draw a grid as text or an image", runRender},
+		{"convert", "convert a grid between file formats", runConvert},
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: hexgrid command [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(w, "\nRun 'hexgrid command -h' for the arguments of a command.")
+}
+
+//  Run the command named by args[0].
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return errUsage
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout)
+		}
+	}
+	return fmt.Errorf("unknown command %q (run hexgrid without arguments for usage)", args[0])
+}
+
+func main() {
+	var err = run(os.Args[1:], os.Stdout)
+	switch {
+	case err == errUsage:
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "hexgrid:", err)
+		os.Exit(1)
+	}
+}
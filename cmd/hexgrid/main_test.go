@@ -0,0 +1,166 @@
+/*
+File: main_test.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package main
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runOutput(T *testing.T, args ...string) string {
+	var buf bytes.Buffer
+	if err := run(args, &buf); err != nil {
+		T.Fatalf("hexgrid %s: %v", strings.Join(args, " "), err)
+	}
+	return buf.String()
+}
+
+func TestCreateConvert(T *testing.T) {
+	var dir, err = ioutil.TempDir("", "hexgrid")
+	if err != nil {
+		T.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var path = filepath.Join(dir, "grid.json")
+	runOutput(T, "create", "-cols", "4", "-rows", "3", "-wrap", "horizontal", "-fill", `{"terrain":"sea"}`, "-o", path)
+	h, err := readGrid(path, "")
+	if err != nil {
+		T.Fatal(err)
+	}
+	if h.NumCols() != 4 || h.NumRows() != 3 || h.Topology() != hexgrid.WrapHorizontal {
+		T.Errorf("read %dx%d grid with topology %v", h.NumCols(), h.NumRows(), h.Topology())
+	}
+	var value, ok = h.TileValue(hexcoords.Hex{0, 0}).(map[string]interface{})
+	if !ok || value["terrain"] != "sea" {
+		T.Errorf("tile value %#v", h.TileValue(hexcoords.Hex{0, 0}))
+	}
+	h.SetEdgeValue(hexcoords.Edge{h.ColMax(), 0, 2, 3}, "river")
+	if err = writeGrid(h, path, "", ioutil.Discard); err != nil {
+		T.Fatal(err)
+	}
+	var data = runOutput(T, "convert", path)
+	if !strings.Contains(data, `"wrap": "horizontal"`) || !strings.Contains(data, `"(-2,1)#5-0": "river"`) {
+		T.Errorf("converted grid:\n%s", data)
+	}
+}
+
+func TestCreateErrors(T *testing.T) {
+	for _, args := range [][]string{
+		{"create", "-cols", "4"},
+		{"create", "-cols", "5", "-wrap", "horizontal"},
+		{"create", "-wrap", "sideways"},
+		{"create", "-f", "doc"},
+		{"frobnicate"},
+	} {
+		if err := run(args, ioutil.Discard); err == nil {
+			T.Errorf("hexgrid %s succeeded", strings.Join(args, " "))
+		}
+	}
+}
+
+func TestInfo(T *testing.T) {
+	var out = runOutput(T, "info", "(0,0)#2")
+	for _, line := range []string{
+		"canonical: (1,0)#0\n",
+		"identical: (0,0)#2 (1,-1)#4 (1,0)#0\n",
+		"tiles:     (0,0) (1,-1) (1,0)\n",
+	} {
+		if !strings.Contains(out, line) {
+			T.Errorf("vertex info missing %q:\n%s", line, out)
+		}
+	}
+	out = runOutput(T, "info", "(0,0)#3-4")
+	if !strings.Contains(out, "identical: (0,0)#3-4 (0,1)#0-1\n") {
+		T.Errorf("edge info:\n%s", out)
+	}
+	out = runOutput(T, "info", "(1,0)")
+	if !strings.Contains(out, "adjacent:  S=(1,-1) SE=(2,0) NE=(2,1) N=(1,1) NW=(0,1) SW=(0,0)\n") {
+		T.Errorf("tile info:\n%s", out)
+	}
+	if err := run([]string{"info", "(0,0)#7"}, ioutil.Discard); err == nil {
+		T.Errorf("invalid coordinate accepted")
+	}
+}
+
+func TestRender(T *testing.T) {
+	var dir, err = ioutil.TempDir("", "hexgrid")
+	if err != nil {
+		T.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var path = filepath.Join(dir, "grid.json")
+	runOutput(T, "create", "-cols", "1", "-rows", "1", "-fill", "hill", "-o", path)
+	var out = runOutput(T, "render", "-label", "value", path)
+	if out != "  +-----+\n /       \\\n+  hill   +\n \\       /\n  +-----+\n" {
+		T.Errorf("ascii rendering:\n%s", out)
+	}
+	for _, ext := range []string{"svg", "png"} {
+		var img = filepath.Join(dir, "grid."+ext)
+		runOutput(T, "render", "-o", img, path)
+		if fi, err := os.Stat(img); err != nil || fi.Size() == 0 {
+			T.Errorf("%s rendering not written: %v", ext, err)
+		}
+	}
+}
+
+func TestConvertMap(T *testing.T) {
+	var dir, err = ioutil.TempDir("", "hexgrid")
+	if err != nil {
+		T.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var (
+		jsonPath = filepath.Join(dir, "grid.json")
+		mapPath  = filepath.Join(dir, "grid.map")
+	)
+	runOutput(T, "create", "-cols", "3", "-rows", "1", "-fill", "sea", "-o", jsonPath)
+	runOutput(T, "convert", "-o", mapPath, jsonPath)
+	var out = runOutput(T, "convert", "-to", "map", mapPath)
+	if !strings.Contains(out, "[legend]\ns = sea\n\n[tiles]\ns s s\n") {
+		T.Errorf("converted map:\n%s", out)
+	}
+	h, err := readGrid(mapPath, "")
+	if err != nil {
+		T.Fatal(err)
+	}
+	if h.TileValue(hexcoords.Hex{1, 0}) != "sea" {
+		T.Errorf("tile value %v", h.TileValue(hexcoords.Hex{1, 0}))
+	}
+}
+
+func TestConvertTiled(T *testing.T) {
+	var dir, err = ioutil.TempDir("", "hexgrid")
+	if err != nil {
+		T.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var (
+		jsonPath = filepath.Join(dir, "grid.json")
+		tmxPath  = filepath.Join(dir, "grid.tmx")
+		tmjPath  = filepath.Join(dir, "grid.tmj")
+	)
+	runOutput(T, "create", "-cols", "3", "-rows", "3", "-fill", "5", "-o", jsonPath)
+	runOutput(T, "convert", "-o", tmxPath, jsonPath)
+	runOutput(T, "convert", "-o", tmjPath, tmxPath)
+	h, err := readGrid(tmjPath, "")
+	if err != nil {
+		T.Fatal(err)
+	}
+	if h.TileValue(hexcoords.Hex{1, 1}) != uint32(5) || h.Radius() != 16 {
+		T.Errorf("tile value %v radius %v", h.TileValue(hexcoords.Hex{1, 1}), h.Radius())
+	}
+	runOutput(T, "create", "-cols", "3", "-rows", "3", "-fill", "sea", "-o", jsonPath)
+	if err = run([]string{"convert", "-o", tmxPath, jsonPath}, ioutil.Discard); err == nil {
+		T.Errorf("converted tile values that are not GIDs")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+File: render.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package main
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/render"
+
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"image/color"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+func runRender(args []string, stdout io.Writer) error {
+	var (
+		fs    = flag.NewFlagSet("render", flag.ContinueOnError)
+		from  = fs.String("from", "", "input format (default from the input file extension, or json)")
+		to    = fs.String("f", "", "output format: ascii, svg or png (default from the output file extension, or ascii)")
+		label = fs.String("label", "coords", "tile labels: none, coords, a1 or value")
+		scale = fs.Float64("scale", 32, "pixels per unit of tile radius, for svg and png")
+		out   = fs.String("o", "", "output file")
+	)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+	var h, err = readGrid(fs.Arg(0), *from)
+	if err != nil {
+		return err
+	}
+	labels, err := labeler(h, *label)
+	if err != nil {
+		return err
+	}
+	var kind = *to
+	if kind == "" {
+		kind = strings.TrimPrefix(strings.ToLower(filepath.Ext(*out)), ".")
+	}
+	var write func(io.Writer) error
+	switch kind {
+	case "", "txt", "ascii":
+		var a = render.ASCII{Label: labels, EdgeMark: edgeMark, VertexMark: vertexMark}
+		write = func(w io.Writer) error { return a.Write(w, h) }
+	case "svg":
+		var s = render.SVG{
+			Scale:       *scale,
+			Label:       labels,
+			Fill:        func(t *hexgrid.Tile) string { return svgColor(valueColor(t.Value)) },
+			EdgeColor:   func(e *hexgrid.Edge) string { return svgColor(valueColor(e.Value)) },
+			VertexColor: func(v *hexgrid.Vertex) string { return svgColor(valueColor(v.Value)) },
+		}
+		write = func(w io.Writer) error { return s.Write(w, h) }
+	case "png":
+		var im = render.Image{
+			Scale:     *scale,
+			Fill:      func(t *hexgrid.Tile) color.Color { return valueColor(t.Value) },
+			EdgeColor: func(e *hexgrid.Edge) color.Color { return valueColor(e.Value) },
+		}
+		write = func(w io.Writer) error { return im.Write(w, h) }
+	default:
+		return fmt.Errorf("unknown render format %q (ascii, svg or png)", kind)
+	}
+	return writeOutput(*out, stdout, write)
+}
+
+//  The tile labels called name.
+func labeler(h *hexgrid.Grid, name string) (func(*hexgrid.Tile) string, error) {
+	switch name {
+	case "none":
+		return nil, nil
+	case "coords":
+		return func(t *hexgrid.Tile) string { return t.Hex.String() }, nil
+	case "a1":
+		return func(t *hexgrid.Tile) string { return h.A1(t.Hex) }, nil
+	case "value":
+		return func(t *hexgrid.Tile) string { return valueString(t.Value) }, nil
+	}
+	return nil, fmt.Errorf("unknown label %q (none, coords, a1 or value)", name)
+}
+
+func valueString(value hexgrid.Value) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+//  Edges and vertices holding a value are marked in ASCII renderings.
+func edgeMark(e *hexgrid.Edge) rune {
+	if e.Value != nil {
+		return '#'
+	}
+	return 0
+}
+
+func vertexMark(v *hexgrid.Vertex) rune {
+	if v.Value != nil {
+		return '*'
+	}
+	return 0
+}
+
+//  A color for value, the same for equal values, or nil for a nil value.
+func valueColor(value hexgrid.Value) color.Color {
+	if value == nil {
+		return nil
+	}
+	var f = fnv.New32a()
+	io.WriteString(f, fmt.Sprint(value))
+	var sum = f.Sum32()
+	// Keep colors light enough for black outlines and labels to show.
+	return color.RGBA{uint8(128 + sum&0x7f), uint8(128 + sum>>8&0x7f), uint8(128 + sum>>16&0x7f), 0xff}
+}
+
+func svgColor(c color.Color) string {
+	if c == nil {
+		return ""
+	}
+	var r, g, b, _ = c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
@@ -0,0 +1,219 @@
+/*
+File: concurrent.go
+Created: Sun Oct 18 23:31:40 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"sync"
+)
+
+//  A ConcurrentGrid guards the values of a Grid's tiles, vertices and edges
+//  so they may be read and written from multiple goroutines. The grid is
+//  divided into square regions of tiles, each protected by its own lock, so
+//  goroutines working in different parts of the map do not contend.
+//
+//  A vertex or edge is guarded by the lock of the region containing its
+//  owner (see hexcoords.Vertex.Canonical and hexcoords.Edge.Canonical).
+//
+//  While the grid records history (see Grid.StartHistory) or has observers
+//  (see Grid.Observe), each write also takes a grid-wide lock to record the
+//  change and queue it for the observers, so writers in different regions
+//  then contend briefly. Values are still stored under the region locks
+//  only. Observers are called after the region lock is released, so they
+//  may read and write values through the ConcurrentGrid.
+//
+//  All access to values must go through the ConcurrentGrid; reading or
+//  assigning the Value field of objects returned by the underlying Grid is
+//  not synchronized.
+type ConcurrentGrid struct {
+	grid       *Grid
+	regionSize int
+	regionRows int
+	locks      []sync.RWMutex
+}
+
+//  Guard the values of h with one lock per regionSize by regionSize block
+//  of tiles. A regionSize less than 1 is treated as 1.
+func NewConcurrentGrid(h *Grid, regionSize int) *ConcurrentGrid {
+	if regionSize < 1 {
+		regionSize = 1
+	}
+	var (
+		cg      = &ConcurrentGrid{grid: h, regionSize: regionSize}
+		regionN = (h.n + regionSize - 1) / regionSize
+	)
+	cg.regionRows = (h.m + regionSize - 1) / regionSize
+	cg.locks = make([]sync.RWMutex, regionN*cg.regionRows)
+	return cg
+}
+
+//  The underlying grid. Its geometry may be used freely, but its values
+//  must not be accessed directly while other goroutines use cg.
+func (cg *ConcurrentGrid) Grid() *Grid {
+	return cg.grid
+}
+
+//  The lock guarding the region containing c. Coordinates outside the
+//  grid (owners of border vertices and edges) use the nearest region.
+func (cg *ConcurrentGrid) lock(c hexcoords.Hex) *sync.RWMutex {
+	var (
+		h    = cg.grid
+		i, j = h.hexIndex(h.wrap(c))
+	)
+	i = imin(imax(i, 0), h.n-1)
+	j = imin(imax(j, 0), h.m-1)
+	return &cg.locks[(i/cg.regionSize)*cg.regionRows+j/cg.regionSize]
+}
+func (cg *ConcurrentGrid) vertexLock(vc hexcoords.Vertex) *sync.RWMutex {
+	return cg.lock(cg.grid.canonicalVertex(vc).Hex())
+}
+func (cg *ConcurrentGrid) edgeLock(e hexcoords.Edge) *sync.RWMutex {
+	return cg.lock(cg.grid.canonicalEdge(e).Hex())
+}
+
+//  See Grid.TileValue.
+func (cg *ConcurrentGrid) TileValue(c hexcoords.Hex) Value {
+	var mu = cg.lock(c)
+	mu.RLock()
+	defer mu.RUnlock()
+	return cg.grid.TileValue(c)
+}
+
+//  See Grid.SetTileValue.
+func (cg *ConcurrentGrid) SetTileValue(c hexcoords.Hex, value Value) {
+	defer cg.grid.deliver()
+	var mu = cg.lock(c)
+	mu.Lock()
+	defer mu.Unlock()
+	cg.grid.setTileValue(c, value)
+}
+
+//  Set the value of the tile at c to new if its current value is old.
+//  Returns true if the value was swapped. Values are compared with ==, which
+//  panics if they are not comparable.
+func (cg *ConcurrentGrid) CompareAndSwapTileValue(c hexcoords.Hex, old, new Value) bool {
+	defer cg.grid.deliver()
+	var mu = cg.lock(c)
+	mu.Lock()
+	defer mu.Unlock()
+	if cg.grid.TileValue(c) != old {
+		return false
+	}
+	cg.grid.setTileValue(c, new)
+	return true
+}
+
+//  Atomically replace the value of the tile at c with fn applied to its
+//  current value. The new value is returned. The function fn must not
+//  access cg.
+func (cg *ConcurrentGrid) UpdateTileValue(c hexcoords.Hex, fn func(Value) Value) Value {
+	defer cg.grid.deliver()
+	var mu = cg.lock(c)
+	mu.Lock()
+	defer mu.Unlock()
+	var value = fn(cg.grid.TileValue(c))
+	cg.grid.setTileValue(c, value)
+	return value
+}
+
+//  See Grid.VertexValue.
+func (cg *ConcurrentGrid) VertexValue(vc hexcoords.Vertex) Value {
+	var mu = cg.vertexLock(vc)
+	mu.RLock()
+	defer mu.RUnlock()
+	return cg.grid.VertexValue(vc)
+}
+
+//  See Grid.SetVertexValue.
+func (cg *ConcurrentGrid) SetVertexValue(vc hexcoords.Vertex, value Value) {
+	defer cg.grid.deliver()
+	var mu = cg.vertexLock(vc)
+	mu.Lock()
+	defer mu.Unlock()
+	cg.grid.setVertexValue(vc, value)
+}
+
+//  See CompareAndSwapTileValue.
+func (cg *ConcurrentGrid) CompareAndSwapVertexValue(vc hexcoords.Vertex, old, new Value) bool {
+	defer cg.grid.deliver()
+	var mu = cg.vertexLock(vc)
+	mu.Lock()
+	defer mu.Unlock()
+	if cg.grid.VertexValue(vc) != old {
+		return false
+	}
+	cg.grid.setVertexValue(vc, new)
+	return true
+}
+
+//  See UpdateTileValue.
+func (cg *ConcurrentGrid) UpdateVertexValue(vc hexcoords.Vertex, fn func(Value) Value) Value {
+	defer cg.grid.deliver()
+	var mu = cg.vertexLock(vc)
+	mu.Lock()
+	defer mu.Unlock()
+	var value = fn(cg.grid.VertexValue(vc))
+	cg.grid.setVertexValue(vc, value)
+	return value
+}
+
+//  See Grid.EdgeValue.
+func (cg *ConcurrentGrid) EdgeValue(e hexcoords.Edge) Value {
+	var mu = cg.edgeLock(e)
+	mu.RLock()
+	defer mu.RUnlock()
+	return cg.grid.EdgeValue(e)
+}
+
+//  See Grid.SetEdgeValue.
+func (cg *ConcurrentGrid) SetEdgeValue(e hexcoords.Edge, value Value) {
+	defer cg.grid.deliver()
+	var mu = cg.edgeLock(e)
+	mu.Lock()
+	defer mu.Unlock()
+	cg.grid.setEdgeValue(e, value)
+}
+
+//  See CompareAndSwapTileValue.
+func (cg *ConcurrentGrid) CompareAndSwapEdgeValue(e hexcoords.Edge, old, new Value) bool {
+	defer cg.grid.deliver()
+	var mu = cg.edgeLock(e)
+	mu.Lock()
+	defer mu.Unlock()
+	if cg.grid.EdgeValue(e) != old {
+		return false
+	}
+	cg.grid.setEdgeValue(e, new)
+	return true
+}
+
+//  See UpdateTileValue.
+func (cg *ConcurrentGrid) UpdateEdgeValue(e hexcoords.Edge, fn func(Value) Value) Value {
+	defer cg.grid.deliver()
+	var mu = cg.edgeLock(e)
+	mu.Lock()
+	defer mu.Unlock()
+	var value = fn(cg.grid.EdgeValue(e))
+	cg.grid.setEdgeValue(e, value)
+	return value
+}
+
+//  A compact copy of the grid's values as of a single instant. Every
+//  region is read-locked while the copy is made, so no write is partially
+//  visible. The snapshot is independent of cg and may be read by any number
+//  of goroutines without further synchronization.
+func (cg *ConcurrentGrid) Snapshot() *Grid {
+	for i := range cg.locks {
+		cg.locks[i].RLock()
+	}
+	defer func() {
+		for i := range cg.locks {
+			cg.locks[i].RUnlock()
+		}
+	}()
+	return cg.grid.compactCopy()
+}
@@ -0,0 +1,179 @@
+/*
+File: concurrent_test.go
+Created: Sun Oct 18 23:31:40 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"sync"
+	"testing"
+)
+
+func TestConcurrentCompareAndSwap(T *testing.T) {
+	var (
+		cg      = NewConcurrentGrid(NewGrid(7, 7, 1, 0, 0, 0), 2)
+		c       = hexcoords.Hex{1, 1}
+		e       = c.Edges(hex.N)[0]
+		wg      sync.WaitGroup
+		workers = 8
+		incrs   = 500
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < incrs; i++ {
+				for {
+					var old = cg.TileValue(c).(int)
+					if cg.CompareAndSwapTileValue(c, old, old+1) {
+						break
+					}
+				}
+				cg.UpdateEdgeValue(e, func(v Value) Value { return v.(int) + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+	if v := cg.TileValue(c); v != workers*incrs {
+		T.Errorf("tile value %v, expected %d", v, workers*incrs)
+	}
+	// The same edge from the neighboring tile.
+	var other = c.Adjacent(hex.N).Edges(hex.S)[0]
+	if v := cg.EdgeValue(other); v != workers*incrs {
+		T.Errorf("edge value %v, expected %d", v, workers*incrs)
+	}
+	if cg.CompareAndSwapVertexValue(hexcoords.Vertex{0, 0, 2}, 1, 2) {
+		T.Errorf("swapped a vertex value that did not match")
+	}
+}
+
+func TestConcurrentSnapshot(T *testing.T) {
+	var (
+		cg   = NewConcurrentGrid(NewGrid(5, 5, 1, 0, 0, 0), 3)
+		c    = hexcoords.Hex{-2, 2}
+		vc   = hexcoords.Vertex{2, 2, 3}
+		snap *Grid
+	)
+	cg.SetTileValue(c, "before")
+	cg.SetVertexValue(vc, "corner")
+	snap = cg.Snapshot()
+	cg.SetTileValue(c, "after")
+	if v := snap.TileValue(c); v != "before" {
+		T.Errorf("snapshot tile value %v", v)
+	}
+	if v := snap.VertexValue(vc); v != "corner" {
+		T.Errorf("snapshot vertex value %v", v)
+	}
+	if !snap.IsCompact() {
+		T.Errorf("snapshot is not compact")
+	}
+}
+
+//  Write every tile of cg's grid, one goroutine per band of columns.
+func writeBands(cg *ConcurrentGrid, bands int) {
+	var (
+		h  = cg.Grid()
+		wg sync.WaitGroup
+	)
+	for b := 0; b < bands; b++ {
+		wg.Add(1)
+		go func(b int) {
+			defer wg.Done()
+			for u := h.ColMin(); u <= h.ColMax(); u++ {
+				if (u-h.ColMin())*bands/h.NumCols() != b {
+					continue
+				}
+				for v := h.RowMin(); v <= h.RowMax(); v++ {
+					cg.SetTileValue(hexcoords.Hex{u, v}, u*v)
+				}
+			}
+		}(b)
+	}
+	wg.Wait()
+}
+
+//  Observers read and write values through the ConcurrentGrid, in the
+//  region being written.
+func TestConcurrentObserverAccess(T *testing.T) {
+	var (
+		h    = NewGrid(7, 7, 1, 0, 0, 0)
+		cg   = NewConcurrentGrid(h, 4)
+		a, b = hexcoords.Hex{0, 0}, hexcoords.Hex{0, 1}
+		seen []Value
+	)
+	h.ObserveTile(a, func(ch Change) {
+		seen = append(seen, cg.TileValue(a))
+		cg.UpdateTileValue(b, func(v Value) Value { return v.(int) + 1 })
+	})
+	cg.SetTileValue(a, 1)
+	cg.CompareAndSwapTileValue(a, 1, 2)
+	cg.UpdateTileValue(a, func(v Value) Value { return v.(int) + 1 })
+	if len(seen) != 3 || seen[0] != 1 || seen[2] != 3 {
+		T.Errorf("observer read %v", seen)
+	}
+	if v := cg.TileValue(b); v != 3 {
+		T.Errorf("value %v written by observer, expected 3", v)
+	}
+}
+
+//  Writers in different regions record into one history. Meaningful under
+//  go test -race.
+func TestConcurrentHistory(T *testing.T) {
+	var (
+		h    = NewGrid(21, 21, 1, nil, nil, nil)
+		hist = h.StartHistory()
+		cg   = NewConcurrentGrid(h, 4)
+	)
+	writeBands(cg, 4)
+	if n := hist.Len(); n != h.NumTiles() {
+		T.Errorf("%d changes recorded, expected %d", n, h.NumTiles())
+	}
+	hist.Rollback("missing")
+	for hist.Undo() {
+	}
+	if v := h.TileValue(hexcoords.Hex{3, 4}); v != nil {
+		T.Errorf("value %v after undoing everything", v)
+	}
+}
+
+//  Observers, including those of a pathfinder, are called by writers in
+//  different regions. Meaningful under go test -race.
+func TestConcurrentObservers(T *testing.T) {
+	var (
+		h    = NewGrid(21, 21, 1, nil, nil, nil)
+		cg   = NewConcurrentGrid(h, 4)
+		seen int
+		cost = StepCost(func(from, to hexcoords.Hex) float64 {
+			if v, ok := cg.TileValue(to).(int); ok && v < 0 {
+				return -1
+			}
+			return 1
+		})
+		p    = h.NewHierarchicalPathfinder(5, cost)
+		hist = h.StartHistory()
+	)
+	defer p.Close()
+	h.Observe(func(Change) { seen++ })
+	var sub = h.ObserveRegion(hexcoords.Hex{0, 0}, hexcoords.Hex{2, 2}, func(Change) {})
+	var done = make(chan bool)
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			h.Observe(func(Change) {}).Cancel()
+		}
+		sub.Cancel()
+	}()
+	writeBands(cg, 4)
+	<-done
+	if seen != h.NumTiles() || hist.Len() != h.NumTiles() {
+		T.Errorf("%d changes observed and %d recorded, expected %d", seen, hist.Len(), h.NumTiles())
+	}
+	var a, b = hexcoords.Hex{-10, 0}, hexcoords.Hex{10, 0}
+	if _, total, ok := p.ShortestPath(a, b); !ok || total != float64(h.Distance(a, b)) {
+		T.Errorf("path from %v to %v costs %v %v", a, b, total, ok)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+File: connect.go
+Created: Mon Oct 19 00:48:22 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+)
+
+//  A set of sides of a grid.
+type Side uint8
+
+const (
+	NorthSide Side = 1 << iota // Tiles in row RowMax().
+	SouthSide                  // Tiles in row RowMin().
+	EastSide                   // Tiles in column ColMax().
+	WestSide                   // Tiles in column ColMin().
+	NoSide    Side = 0
+	AllSides  Side = NorthSide | SouthSide | EastSide | WestSide
+)
+
+//  Connections tracks groups of adjacent tiles with the same owner, as in
+//  the game of Hex, and the sides of the grid each group touches. The owner
+//  of a tile is its value; tiles with a nil value are unowned.
+//
+//  Connections is bound to a grid and observes it. Claiming an unowned
+//  tile with SetTileValue is processed incrementally with a union-find
+//  structure, in nearly constant time. Any other change to a tile value
+//  (removing or changing an owner, as when undoing a move) causes the
+//  groups to be recomputed.
+type Connections struct {
+	grid     *Grid
+	sub      *Subscription
+	parent   []int32 // By tile slot. Unowned tiles are -1.
+	size     []int32
+	sides    []Side // Sides touched by the group of each root.
+	achieved map[connection]bool
+}
+
+//  A player and a set of sides connected by one of their groups.
+type connection struct {
+	owner Value
+	sides Side
+}
+
+//  Track the groups of owned tiles of h. Owners must be comparable values.
+func NewConnections(h *Grid) *Connections {
+	var cn = &Connections{
+		grid:   h,
+		parent: make([]int32, h.n*h.m),
+		size:   make([]int32, h.n*h.m),
+		sides:  make([]Side, h.n*h.m),
+	}
+	cn.rebuild()
+	cn.sub = h.Observe(func(ch Change) {
+		if ch.Kind != TileChange {
+			return
+		}
+		if ch.Old == nil && ch.New != nil {
+			cn.add(ch.Hex)
+		} else if ch.Old != ch.New {
+			cn.rebuild()
+		}
+	})
+	return cn
+}
+
+//  Stop observing the grid. The groups are no longer updated.
+func (cn *Connections) Close() {
+	cn.sub.Cancel()
+}
+
+func (cn *Connections) rebuild() {
+	for i := range cn.parent {
+		cn.parent[i] = -1
+	}
+	cn.achieved = make(map[connection]bool)
+	var h = cn.grid
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			var c = hexcoords.Hex{u, v}
+			if h.TileValue(c) != nil {
+				cn.add(c)
+			}
+		}
+	}
+}
+
+//  The sides of the grid the tile at c lies on.
+func (cn *Connections) tileSides(c hexcoords.Hex) Side {
+	var (
+		h     = cn.grid
+		sides = NoSide
+	)
+	if c.V == h.RowMax() {
+		sides |= NorthSide
+	}
+	if c.V == h.RowMin() {
+		sides |= SouthSide
+	}
+	if c.U == h.ColMax() {
+		sides |= EastSide
+	}
+	if c.U == h.ColMin() {
+		sides |= WestSide
+	}
+	return sides
+}
+
+//  Add the newly owned tile at c and merge it with adjacent groups of the
+//  same owner.
+func (cn *Connections) add(c hexcoords.Hex) {
+	var (
+		h     = cn.grid
+		slot  = int32(h.tileSlot(c))
+		owner = h.TileValue(c)
+	)
+	if cn.parent[slot] >= 0 {
+		return
+	}
+	cn.parent[slot] = slot
+	cn.size[slot] = 1
+	cn.sides[slot] = cn.tileSides(c)
+	h.eachAdjacent(c, func(adj hexcoords.Hex) {
+		if h.TileValue(adj) != owner {
+			return
+		}
+		var adjSlot = int32(h.tileSlot(adj))
+		if cn.parent[adjSlot] >= 0 {
+			cn.union(slot, adjSlot)
+		}
+	})
+	cn.achieved[connection{owner, cn.sides[cn.find(slot)]}] = true
+}
+
+func (cn *Connections) find(slot int32) int32 {
+	for cn.parent[slot] != slot {
+		cn.parent[slot] = cn.parent[cn.parent[slot]]
+		slot = cn.parent[slot]
+	}
+	return slot
+}
+
+func (cn *Connections) union(a, b int32) {
+	a, b = cn.find(a), cn.find(b)
+	if a == b {
+		return
+	}
+	if cn.size[a] < cn.size[b] {
+		a, b = b, a
+	}
+	cn.parent[b] = a
+	cn.size[a] += cn.size[b]
+	cn.sides[a] |= cn.sides[b]
+}
+
+//  The root slot of the group containing c, or -1 if c is unowned or not
+//  within the bounds of the grid.
+func (cn *Connections) root(c hexcoords.Hex) int32 {
+	c, ok := cn.grid.Wrap(c)
+	if !ok {
+		return -1
+	}
+	var slot = int32(cn.grid.tileSlot(c))
+	if cn.parent[slot] < 0 {
+		return -1
+	}
+	return cn.find(slot)
+}
+
+//  Returns true if the tiles at a and b are owned and belong to the same
+//  group.
+func (cn *Connections) Connected(a, b hexcoords.Hex) bool {
+	var ra = cn.root(a)
+	return ra >= 0 && ra == cn.root(b)
+}
+
+//  The number of tiles in the group containing c. Returns 0 if c is
+//  unowned.
+func (cn *Connections) GroupSize(c hexcoords.Hex) int {
+	var r = cn.root(c)
+	if r < 0 {
+		return 0
+	}
+	return int(cn.size[r])
+}
+
+//  The sides of the grid touched by the group containing c.
+func (cn *Connections) Sides(c hexcoords.Hex) Side {
+	var r = cn.root(c)
+	if r < 0 {
+		return NoSide
+	}
+	return cn.sides[r]
+}
+
+//  Returns true if a single group of tiles owned by owner touches all of
+//  the given sides. In the game of Hex, a player connecting north and south
+//  has won when HasConnected(player, NorthSide|SouthSide) is true.
+func (cn *Connections) HasConnected(owner Value, sides Side) bool {
+	for s := sides; s <= AllSides; s++ {
+		if s&sides == sides && cn.achieved[connection{owner, s}] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+/*
+File: connect_test.go
+Created: Mon Oct 19 00:48:22 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestConnectionsHex(T *testing.T) {
+	var (
+		h  = NewGrid(5, 5, 1, nil, nil, nil)
+		cn = NewConnections(h)
+	)
+	defer cn.Close()
+	h.StartHistory()
+	for v := h.RowMin(); v <= h.RowMax(); v++ {
+		if cn.HasConnected("red", NorthSide|SouthSide) {
+			T.Fatalf("red connected before row %d", v)
+		}
+		h.SetTileValue(hexcoords.Hex{0, v}, "red")
+		h.SetTileValue(hexcoords.Hex{1, v}, "blue")
+	}
+	if !cn.HasConnected("red", NorthSide|SouthSide) {
+		T.Errorf("red column not connected")
+	}
+	if cn.HasConnected("red", EastSide) || cn.HasConnected("blue", WestSide) {
+		T.Errorf("connected to a side not reached")
+	}
+	if !cn.Connected(hexcoords.Hex{0, -2}, hexcoords.Hex{0, 2}) {
+		T.Errorf("column ends not connected")
+	}
+	if cn.Connected(hexcoords.Hex{0, 0}, hexcoords.Hex{1, 0}) {
+		T.Errorf("different owners connected")
+	}
+	if n := cn.GroupSize(hexcoords.Hex{0, 0}); n != 5 {
+		T.Errorf("group size %d", n)
+	}
+
+	// Undoing a move breaks the chain.
+	h.History().Undo()
+	h.History().Undo()
+	if cn.HasConnected("red", NorthSide|SouthSide) {
+		T.Errorf("red still connected after undo")
+	}
+	if s := cn.Sides(hexcoords.Hex{0, 0}); s != SouthSide {
+		T.Errorf("sides %v after undo", s)
+	}
+}
+
+func TestConnectionsInitial(T *testing.T) {
+	var h = NewGrid(3, 3, 1, func(c hexcoords.Hex) Value {
+		if c.U == -1 {
+			return "x"
+		}
+		return nil
+	}, nil, nil)
+	var cn = NewConnections(h)
+	if !cn.HasConnected("x", NorthSide|SouthSide|WestSide) {
+		T.Errorf("initial column not connected")
+	}
+	if cn.Sides(hexcoords.Hex{0, 0}) != NoSide {
+		T.Errorf("unowned tile has sides")
+	}
+}
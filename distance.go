@@ -0,0 +1,214 @@
+/*
+File: distance.go
+Created: Mon Oct 19 06:02:15 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"container/heap"
+	"math"
+)
+
+//  A DistanceField holds, for every tile of a grid, the cost of the
+//  cheapest path from the tile to the nearest of a set of source tiles,
+//  such as enemy units or resources. Path costs are given by a StepCost
+//  and paths cross wrapping borders. Tiles that cannot reach a source are
+//  at an infinite distance.
+//
+//  The field is computed with a multi-source Dijkstra search and updated
+//  incrementally as sources are added, removed and moved. It does not
+//  observe its grid; call Recompute after changes affecting step costs.
+type DistanceField struct {
+	grid    *Grid
+	cost    StepCost
+	sources map[int]bool
+	dist    []float64 // By tile slot.
+	nearest []int32   // The source slot nearest each tile, or -1.
+}
+
+//  A distance field of h for the given sources.
+func (h *Grid) NewDistanceField(cost StepCost, sources ...hexcoords.Hex) *DistanceField {
+	var f = &DistanceField{
+		grid:    h,
+		cost:    cost,
+		sources: make(map[int]bool),
+		dist:    make([]float64, h.n*h.m),
+		nearest: make([]int32, h.n*h.m),
+	}
+	for _, c := range sources {
+		if c, ok := h.Wrap(c); ok {
+			f.sources[h.tileSlot(c)] = true
+		}
+	}
+	f.Recompute()
+	return f
+}
+
+//  Compute the field from scratch.
+func (f *DistanceField) Recompute() {
+	for slot := range f.dist {
+		f.dist[slot] = math.Inf(1)
+		f.nearest[slot] = -1
+	}
+	var open = new(slotQueue)
+	for slot := range f.sources {
+		f.dist[slot] = 0
+		f.nearest[slot] = int32(slot)
+		heap.Push(open, slotItem{slot, 0})
+	}
+	f.propagate(open)
+}
+
+//  Relax distances outward from the queued tiles, whose distances are
+//  set, until no distance can be reduced.
+func (f *DistanceField) propagate(open *slotQueue) {
+	var h = f.grid
+	for open.Len() > 0 {
+		var item = heap.Pop(open).(slotItem)
+		if item.priority > f.dist[item.slot] {
+			continue
+		}
+		var c = h.slotHex(item.slot)
+		h.eachAdjacent(c, func(adj hexcoords.Hex) {
+			var step = f.cost.between(adj, c)
+			if !passable(step) {
+				return
+			}
+			var (
+				adjSlot = h.tileSlot(adj)
+				d       = item.priority + step
+			)
+			if d < f.dist[adjSlot] {
+				f.dist[adjSlot] = d
+				f.nearest[adjSlot] = f.nearest[item.slot]
+				heap.Push(open, slotItem{adjSlot, d})
+			}
+		})
+	}
+}
+
+//  The distance from the tile at c to the nearest source. Returns +Inf if
+//  no source can be reached or c is not a tile of the grid.
+func (f *DistanceField) Distance(c hexcoords.Hex) float64 {
+	c, ok := f.grid.Wrap(c)
+	if !ok {
+		return math.Inf(1)
+	}
+	return f.dist[f.grid.tileSlot(c)]
+}
+
+//  The source nearest the tile at c. The second return value is false if
+//  no source can be reached from c.
+func (f *DistanceField) Nearest(c hexcoords.Hex) (hexcoords.Hex, bool) {
+	c, ok := f.grid.Wrap(c)
+	if !ok {
+		return hexcoords.Hex{}, false
+	}
+	var src = f.nearest[f.grid.tileSlot(c)]
+	if src < 0 {
+		return hexcoords.Hex{}, false
+	}
+	return f.grid.slotHex(int(src)), true
+}
+
+//  The sources of the field, in no particular order.
+func (f *DistanceField) Sources() []hexcoords.Hex {
+	var sources = make([]hexcoords.Hex, 0, len(f.sources))
+	for slot := range f.sources {
+		sources = append(sources, f.grid.slotHex(slot))
+	}
+	return sources
+}
+
+//  Returns true if the tile at c is a source.
+func (f *DistanceField) IsSource(c hexcoords.Hex) bool {
+	c, ok := f.grid.Wrap(c)
+	return ok && f.sources[f.grid.tileSlot(c)]
+}
+
+//  Make the tile at c a source. Only tiles closer to c than to the other
+//  sources are updated.
+func (f *DistanceField) AddSource(c hexcoords.Hex) {
+	c, ok := f.grid.Wrap(c)
+	if !ok {
+		return
+	}
+	var slot = f.grid.tileSlot(c)
+	if f.sources[slot] {
+		return
+	}
+	f.sources[slot] = true
+	f.dist[slot] = 0
+	f.nearest[slot] = int32(slot)
+	var open = new(slotQueue)
+	heap.Push(open, slotItem{slot, 0})
+	f.propagate(open)
+}
+
+//  Stop the tile at c being a source. Only tiles whose nearest source was
+//  c are updated.
+func (f *DistanceField) RemoveSource(c hexcoords.Hex) {
+	c, ok := f.grid.Wrap(c)
+	if !ok {
+		return
+	}
+	var slot = f.grid.tileSlot(c)
+	if !f.sources[slot] {
+		return
+	}
+	delete(f.sources, slot)
+	var invalid []int
+	for s, src := range f.nearest {
+		if int(src) == slot {
+			f.dist[s] = math.Inf(1)
+			f.nearest[s] = -1
+			invalid = append(invalid, s)
+		}
+	}
+	f.reseed(invalid)
+}
+
+//  Recompute the distances of the given tiles, whose distances are
+//  infinite, from the tiles around them.
+func (f *DistanceField) reseed(invalid []int) {
+	var (
+		h    = f.grid
+		open = new(slotQueue)
+	)
+	for _, slot := range invalid {
+		var c = h.slotHex(slot)
+		h.eachAdjacent(c, func(adj hexcoords.Hex) {
+			var adjSlot = h.tileSlot(adj)
+			if f.nearest[adjSlot] < 0 {
+				return
+			}
+			var step = f.cost.between(c, adj)
+			if !passable(step) {
+				return
+			}
+			if d := f.dist[adjSlot] + step; d < f.dist[slot] {
+				f.dist[slot] = d
+				f.nearest[slot] = f.nearest[adjSlot]
+			}
+		})
+		if f.nearest[slot] >= 0 {
+			heap.Push(open, slotItem{slot, f.dist[slot]})
+		}
+	}
+	f.propagate(open)
+}
+
+//  Move the source at from to the tile at to, as when a unit moves. Does
+//  nothing if from is not a source or to is not a tile of the grid. Adding
+//  the new source first limits the update to the tiles nearer from than to.
+func (f *DistanceField) MoveSource(from, to hexcoords.Hex) {
+	to, ok := f.grid.Wrap(to)
+	if !ok || !f.IsSource(from) || f.grid.wrap(from) == to {
+		return
+	}
+	f.AddSource(to)
+	f.RemoveSource(from)
+}
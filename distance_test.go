@@ -0,0 +1,135 @@
+/*
+File: distance_test.go
+Created: Mon Oct 19 06:02:15 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDistanceFieldUniform(T *testing.T) {
+	var (
+		h       = NewWrappedGrid(10, 7, 1, WrapHorizontal, nil, nil, nil)
+		sources = []hexcoords.Hex{{-4, 0}, {3, 2}}
+		f       = h.NewDistanceField(nil, sources...)
+	)
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			var (
+				c        = hexcoords.Hex{u, v}
+				expected = imin(h.Distance(c, sources[0]), h.Distance(c, sources[1]))
+			)
+			if d := f.Distance(c); d != float64(expected) {
+				T.Errorf("distance of %v is %v, expected %v", c, d, expected)
+			}
+			var src, ok = f.Nearest(c)
+			if !ok || float64(h.Distance(c, src)) != f.Distance(c) {
+				T.Errorf("nearest source of %v is %v %v", c, src, ok)
+			}
+		}
+	}
+	if d := f.Distance(hexcoords.Hex{0, 10}); !math.IsInf(d, 1) {
+		T.Errorf("distance outside the grid %v", d)
+	}
+}
+
+//  Step costs from tile values, with nil values impassable.
+func testTerrainCost(h *Grid) StepCost {
+	return h.TileCost(func(v Value) float64 {
+		if v == nil {
+			return -1
+		}
+		return v.(float64)
+	})
+}
+
+func randomTerrain(h *Grid, rng *rand.Rand) {
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			if rng.Intn(6) > 0 {
+				h.SetTileValue(hexcoords.Hex{u, v}, float64(1+rng.Intn(4)))
+			}
+		}
+	}
+}
+
+func randomHex(h *Grid, rng *rand.Rand) hexcoords.Hex {
+	return hexcoords.Hex{h.ColMin() + rng.Intn(h.NumCols()), h.RowMin() + rng.Intn(h.NumRows())}
+}
+
+func TestDistanceFieldIncremental(T *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(7))
+		h   = NewGrid(15, 11, 1, nil, nil, nil)
+	)
+	randomTerrain(h, rng)
+	var (
+		cost = testTerrainCost(h)
+		f    = h.NewDistanceField(cost, randomHex(h, rng), randomHex(h, rng))
+	)
+	for i := 0; i < 60; i++ {
+		var sources = f.Sources()
+		switch op := rng.Intn(3); {
+		case op == 0 || len(sources) == 0:
+			f.AddSource(randomHex(h, rng))
+		case op == 1 && len(sources) > 1:
+			f.RemoveSource(sources[rng.Intn(len(sources))])
+		default:
+			var from = sources[rng.Intn(len(sources))]
+			f.MoveSource(from, from.Adjacent(tileDirections[rng.Intn(6)]))
+		}
+		var fresh = h.NewDistanceField(cost, f.Sources()...)
+		for slot := range f.dist {
+			if f.dist[slot] != fresh.dist[slot] {
+				T.Fatalf("step %d: distance of %v is %v, expected %v", i, h.slotHex(slot), f.dist[slot], fresh.dist[slot])
+			}
+		}
+	}
+}
+
+func TestDistanceFieldMoveOffGrid(T *testing.T) {
+	var (
+		h    = NewGrid(5, 5, 1, nil, nil, nil)
+		edge = hexcoords.Hex{h.ColMax(), 0}
+		f    = h.NewDistanceField(nil, edge)
+	)
+	f.MoveSource(edge, hexcoords.Hex{h.ColMax() + 1, 0})
+	if s := f.Sources(); len(s) != 1 || s[0] != edge {
+		T.Errorf("sources %v after moving off the grid", s)
+	}
+	if d := f.Distance(hexcoords.Hex{h.ColMin(), 0}); d != float64(h.NumCols()-1) {
+		T.Errorf("distance %v from the unmoved source", d)
+	}
+}
+
+func TestDistanceFieldCost(T *testing.T) {
+	// A wall of impassable tiles with one gap, and expensive tiles beside
+	// the source.
+	var h = NewGrid(7, 7, 1, 1.0, nil, nil)
+	for v := h.RowMin(); v < h.RowMax(); v++ {
+		h.SetTileValue(hexcoords.Hex{0, v}, nil)
+	}
+	h.SetTileValue(hexcoords.Hex{-2, 0}, 5.0)
+	var f = h.NewDistanceField(testTerrainCost(h), hexcoords.Hex{-3, 0})
+	// Costs are paid on entering a tile, so leaving the expensive tile
+	// for the source costs the source's value.
+	if d := f.Distance(hexcoords.Hex{-2, 0}); d != 1 {
+		T.Errorf("distance from expensive tile %v", d)
+	}
+	if d := f.Distance(hexcoords.Hex{-1, 0}); d != 2 {
+		T.Errorf("distance around expensive tile %v", d)
+	}
+	path, cost, ok := h.ShortestPath(hexcoords.Hex{3, 0}, hexcoords.Hex{-3, 0}, testTerrainCost(h))
+	if !ok || f.Distance(hexcoords.Hex{3, 0}) != cost {
+		T.Errorf("distance %v, shortest path %v cost %v", f.Distance(hexcoords.Hex{3, 0}), path, cost)
+	}
+	if d := f.Distance(hexcoords.Hex{1, 0}); d <= float64(h.Distance(hexcoords.Hex{1, 0}, hexcoords.Hex{-3, 0})) {
+		T.Errorf("distance %v through the wall", d)
+	}
+}
@@ -0,0 +1,233 @@
+/*
+File: flow.go
+Created: Mon Oct 19 07:55:03 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"container/heap"
+	"math"
+)
+
+//  A FlowField gives, for every tile of a grid, the direction of the first
+//  step of a cheapest path to a goal tile, so that any number of units can
+//  move toward the goal by looking up their tile. It is computed with a
+//  single Dijkstra search out from the goal, with path costs given by a
+//  StepCost and paths crossing wrapping borders.
+//
+//  The field does not observe its grid. When step costs change, Update
+//  recomputes only the tiles whose paths ran through the changed tiles and
+//  those that can now reach the goal more cheaply.
+type FlowField struct {
+	grid *Grid
+	cost StepCost
+	goal int
+	dist []float64       // By tile slot.
+	dirs []hex.Direction // By tile slot.
+}
+
+//  A flow field of h toward the tile at goal. Returns nil if goal is not a
+//  tile of h.
+func (h *Grid) NewFlowField(cost StepCost, goal hexcoords.Hex) *FlowField {
+	goal, ok := h.Wrap(goal)
+	if !ok {
+		return nil
+	}
+	var f = &FlowField{
+		grid: h,
+		cost: cost,
+		goal: h.tileSlot(goal),
+		dist: make([]float64, h.n*h.m),
+		dirs: make([]hex.Direction, h.n*h.m),
+	}
+	f.Recompute()
+	return f
+}
+
+//  Compute the field from scratch.
+func (f *FlowField) Recompute() {
+	for slot := range f.dist {
+		f.dist[slot] = math.Inf(1)
+		f.dirs[slot] = hex.NilDirection
+	}
+	f.dist[f.goal] = 0
+	var open = new(slotQueue)
+	heap.Push(open, slotItem{f.goal, 0})
+	f.propagate(open)
+}
+
+//  Relax distances outward from the queued tiles, whose distances are
+//  set, pointing each improved tile at the tile it was reached from.
+func (f *FlowField) propagate(open *slotQueue) {
+	var h = f.grid
+	for open.Len() > 0 {
+		var item = heap.Pop(open).(slotItem)
+		if item.priority > f.dist[item.slot] {
+			continue
+		}
+		var c = h.slotHex(item.slot)
+		for _, dir := range tileDirections {
+			var adj, ok = h.Wrap(c.Adjacent(dir))
+			if !ok {
+				continue
+			}
+			var step = f.cost.between(adj, c)
+			if !passable(step) {
+				continue
+			}
+			var (
+				adjSlot = h.tileSlot(adj)
+				d       = item.priority + step
+			)
+			if d < f.dist[adjSlot] {
+				f.dist[adjSlot] = d
+				f.dirs[adjSlot] = dir.Inverse()
+				heap.Push(open, slotItem{adjSlot, d})
+			}
+		}
+	}
+}
+
+//  The slot of the tile the tile in slot flows into, or -1.
+func (f *FlowField) next(slot int) int {
+	var dir = f.dirs[slot]
+	if dir == hex.NilDirection {
+		return -1
+	}
+	return f.grid.tileSlot(f.grid.wrap(f.grid.slotHex(slot).Adjacent(dir)))
+}
+
+//  The goal of the field.
+func (f *FlowField) Goal() hexcoords.Hex {
+	return f.grid.slotHex(f.goal)
+}
+
+//  Direct the field toward the tile at goal and recompute it. Does nothing
+//  if goal is not a tile of the grid.
+func (f *FlowField) SetGoal(goal hexcoords.Hex) {
+	goal, ok := f.grid.Wrap(goal)
+	if !ok {
+		return
+	}
+	f.goal = f.grid.tileSlot(goal)
+	f.Recompute()
+}
+
+//  The direction to move from the tile at c toward the goal. Returns
+//  hex.NilDirection at the goal, on tiles that cannot reach it and outside
+//  the grid.
+func (f *FlowField) Direction(c hexcoords.Hex) hex.Direction {
+	c, ok := f.grid.Wrap(c)
+	if !ok {
+		return hex.NilDirection
+	}
+	return f.dirs[f.grid.tileSlot(c)]
+}
+
+//  The tile to move to from the tile at c toward the goal. The second
+//  return value is false where Direction is hex.NilDirection.
+func (f *FlowField) Next(c hexcoords.Hex) (hexcoords.Hex, bool) {
+	c, ok := f.grid.Wrap(c)
+	if !ok {
+		return hexcoords.Hex{}, false
+	}
+	var slot = f.next(f.grid.tileSlot(c))
+	if slot < 0 {
+		return hexcoords.Hex{}, false
+	}
+	return f.grid.slotHex(slot), true
+}
+
+//  The cost of the path from the tile at c to the goal. Returns +Inf if
+//  the goal cannot be reached or c is not a tile of the grid.
+func (f *FlowField) Distance(c hexcoords.Hex) float64 {
+	c, ok := f.grid.Wrap(c)
+	if !ok {
+		return math.Inf(1)
+	}
+	return f.dist[f.grid.tileSlot(c)]
+}
+
+//  The direction of every tile, indexed by Grid.TileIndex. The slice is
+//  owned by the field and changes as it is updated; it must not be
+//  modified.
+func (f *FlowField) Directions() []hex.Direction {
+	return f.dirs
+}
+
+//  Update the field after the costs of moving into or out of the tiles at
+//  cs changed. The tiles whose paths led through cs are cleared and
+//  recomputed from the tiles around them, then improvements spread out
+//  from cs. Other tiles are not visited.
+func (f *FlowField) Update(cs ...hexcoords.Hex) {
+	var (
+		h       = f.grid
+		seen    = make(map[int]bool)
+		invalid []int
+		changed []int
+	)
+	for _, c := range cs {
+		c, ok := h.Wrap(c)
+		if !ok {
+			continue
+		}
+		var slot = h.tileSlot(c)
+		changed = append(changed, slot)
+		if seen[slot] {
+			continue
+		}
+		seen[slot] = true
+		for queue := []int{slot}; len(queue) > 0; queue = queue[1:] {
+			var s = queue[0]
+			invalid = append(invalid, s)
+			h.eachAdjacent(h.slotHex(s), func(adj hexcoords.Hex) {
+				var adjSlot = h.tileSlot(adj)
+				if !seen[adjSlot] && f.next(adjSlot) == s {
+					seen[adjSlot] = true
+					queue = append(queue, adjSlot)
+				}
+			})
+		}
+	}
+	for _, slot := range invalid {
+		if slot != f.goal {
+			f.dist[slot] = math.Inf(1)
+			f.dirs[slot] = hex.NilDirection
+		}
+	}
+
+	var open = new(slotQueue)
+	for _, slot := range invalid {
+		if slot == f.goal {
+			continue
+		}
+		var c = h.slotHex(slot)
+		for _, dir := range tileDirections {
+			var adj, ok = h.Wrap(c.Adjacent(dir))
+			if !ok {
+				continue
+			}
+			var step = f.cost.between(c, adj)
+			if !passable(step) {
+				continue
+			}
+			if d := f.dist[h.tileSlot(adj)] + step; d < f.dist[slot] {
+				f.dist[slot] = d
+				f.dirs[slot] = dir
+			}
+		}
+		if !math.IsInf(f.dist[slot], 1) {
+			heap.Push(open, slotItem{slot, f.dist[slot]})
+		}
+	}
+	for _, slot := range changed {
+		if !math.IsInf(f.dist[slot], 1) {
+			heap.Push(open, slotItem{slot, f.dist[slot]})
+		}
+	}
+	f.propagate(open)
+}
@@ -0,0 +1,118 @@
+/*
+File: flow_test.go
+Created: Mon Oct 19 07:55:03 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFlowFieldUniform(T *testing.T) {
+	var (
+		h    = NewWrappedGrid(12, 9, 1, WrapHorizontal, nil, nil, nil)
+		goal = hexcoords.Hex{5, -2}
+		f    = h.NewFlowField(nil, goal)
+	)
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			var c = hexcoords.Hex{u, v}
+			if d := f.Distance(c); d != float64(h.Distance(c, goal)) {
+				T.Errorf("distance of %v is %v, expected %v", c, d, h.Distance(c, goal))
+			}
+			var steps int
+			for at := c; at != goal; steps++ {
+				var next, ok = f.Next(at)
+				if !ok || steps > h.NumTiles() {
+					T.Fatalf("flow from %v stops at %v", c, at)
+				}
+				if h.wrap(at.Adjacent(f.Direction(at))) != next {
+					T.Fatalf("direction %v from %v does not lead to %v", f.Direction(at), at, next)
+				}
+				at = next
+			}
+			if steps != h.Distance(c, goal) {
+				T.Errorf("flow from %v takes %d steps", c, steps)
+			}
+		}
+	}
+	if f.Direction(goal) != hex.NilDirection {
+		T.Errorf("direction at the goal %v", f.Direction(goal))
+	}
+	if i, ok := h.TileIndex(hexcoords.Hex{-3, 1}); !ok || f.Directions()[i] != f.Direction(hexcoords.Hex{-3, 1}) {
+		T.Errorf("tile index %d %v", i, ok)
+	}
+	if h.NewFlowField(nil, hexcoords.Hex{0, 20}) != nil {
+		T.Errorf("flow field toward a tile outside the grid")
+	}
+}
+
+//  Check that every tile of f flows to a neighbor at the right cost.
+func checkFlow(T *testing.T, h *Grid, f *FlowField, cost StepCost) {
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			var (
+				c        = hexcoords.Hex{u, v}
+				next, ok = f.Next(c)
+				d        = f.Distance(c)
+			)
+			if !ok {
+				if c != f.Goal() && !math.IsInf(d, 1) {
+					T.Errorf("no direction at %v, distance %v", c, d)
+				}
+				continue
+			}
+			if step := cost.between(c, next); f.Distance(next)+step != d {
+				T.Errorf("%v flows to %v: %v + %v != %v", c, next, f.Distance(next), step, d)
+			}
+		}
+	}
+}
+
+func TestFlowFieldUpdate(T *testing.T) {
+	var (
+		h    = NewWrappedGrid(20, 15, 1, WrapHorizontal, nil, nil, nil)
+		rng  = rand.New(rand.NewSource(13))
+		cost = testTerrainCost(h)
+	)
+	randomTerrain(h, rng)
+	var goal = randomHex(h, rng)
+	h.SetTileValue(goal, 1.0)
+	var f = h.NewFlowField(cost, goal)
+	checkFlow(T, h, f, cost)
+	for round := 0; round < 20; round++ {
+		var changed []hexcoords.Hex
+		for k := rng.Intn(4); k >= 0; k-- {
+			var c = randomHex(h, rng)
+			if rng.Intn(3) == 0 {
+				h.SetTileValue(c, nil)
+			} else {
+				h.SetTileValue(c, float64(1+rng.Intn(4)))
+			}
+			changed = append(changed, c)
+		}
+		f.Update(changed...)
+		checkFlow(T, h, f, cost)
+		var fresh = h.NewFlowField(cost, goal)
+		for u := h.ColMin(); u <= h.ColMax(); u++ {
+			for v := h.RowMin(); v <= h.RowMax(); v++ {
+				var c = hexcoords.Hex{u, v}
+				if f.Distance(c) != fresh.Distance(c) {
+					T.Fatalf("round %d: distance of %v is %v, recomputed %v", round, c, f.Distance(c), fresh.Distance(c))
+				}
+			}
+		}
+	}
+
+	f.SetGoal(hexcoords.Hex{0, 0})
+	if f.Goal() != (hexcoords.Hex{0, 0}) || f.Distance(hexcoords.Hex{0, 0}) != 0 {
+		T.Errorf("goal %v at distance %v", f.Goal(), f.Distance(f.Goal()))
+	}
+	checkFlow(T, h, f, cost)
+}
@@ -0,0 +1,125 @@
+/*
+File: geometry.go
+Created: Sun Oct 18 22:14:32 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"math"
+)
+
+//  Returns true if p lies inside hex or on its boundary. The hexagon need
+//  not be regular (see Transform), but it must not be self-intersecting.
+func (hex *HexPoints) Contains(p point.Point) bool {
+	var inside = false
+	for k := range hex {
+		var (
+			a = hex[k]
+			b = hex[(k+1)%len(hex)]
+		)
+		if (point.Segment{a, b}).Distance(p) < point.PointApproximationGap {
+			return true
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) {
+			var x = a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if p.X < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+//  The signed area of hex, computed with the shoelace formula. The result
+//  is positive because hexagon corners are ordered counter-clockwise.
+func (hex *HexPoints) signedArea() float64 {
+	var sum float64
+	for k := range hex {
+		sum += hex[k].Cross(hex[(k+1)%len(hex)])
+	}
+	return sum / 2
+}
+
+//  The area enclosed by hex.
+func (hex *HexPoints) Area() float64 {
+	return math.Abs(hex.signedArea())
+}
+
+//  The center of mass of the area enclosed by hex. For a hexagon returned
+//  by Grid.GetHex this is the tile's center.
+func (hex *HexPoints) Centroid() point.Point {
+	var (
+		area = hex.signedArea()
+		c    point.Point
+	)
+	if area == 0 {
+		for _, p := range hex {
+			c = c.Add(p)
+		}
+		return c.Scale(1.0 / float64(len(hex)))
+	}
+	for k := range hex {
+		var (
+			a     = hex[k]
+			b     = hex[(k+1)%len(hex)]
+			cross = a.Cross(b)
+		)
+		c = c.Add(a.Add(b).Scale(cross))
+	}
+	return c.Scale(1 / (6 * area))
+}
+
+//  The smallest axis-aligned rectangle containing hex.
+func (hex *HexPoints) Bounds() point.Rect {
+	return point.Bounds(hex[:]...)
+}
+
+//  The sides of hex, with side k running from corner k to corner k+1.
+func (hex *HexPoints) Segments() []point.Segment {
+	var segs = make([]point.Segment, len(hex))
+	for k := range hex {
+		segs[k] = point.Segment{hex[k], hex[(k+1)%len(hex)]}
+	}
+	return segs
+}
+
+//  The smallest axis-aligned rectangle containing every tile of h.
+func (h *Grid) Bounds() point.Rect {
+	var r = point.EmptyRect()
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		r = r.Union(h.GetHex(hexcoords.Hex{u, h.RowMin()}).Bounds())
+		r = r.Union(h.GetHex(hexcoords.Hex{u, h.RowMax()}).Bounds())
+	}
+	return r
+}
+
+//  The distance from p to the nearest point of hex. Returns zero if p
+//  lies inside hex.
+func (hex *HexPoints) Distance(p point.Point) float64 {
+	if hex.Contains(p) {
+		return 0
+	}
+	var dist = math.Inf(1)
+	for _, side := range hex.Segments() {
+		dist = math.Min(dist, side.Distance(p))
+	}
+	return dist
+}
+
+//  Returns true if hex and r share at least one point.
+func (hex *HexPoints) IntersectsRect(r point.Rect) bool {
+	if !hex.Bounds().Intersects(r) {
+		return false
+	}
+	for _, side := range hex.Segments() {
+		if side.IntersectsRect(r) {
+			return true
+		}
+	}
+	// Either r is entirely inside hex or they are disjoint.
+	return hex.Contains(r.Min)
+}
@@ -0,0 +1,56 @@
+/*
+File: geometry_test.go
+Created: Sun Oct 18 22:14:32 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"math"
+	"testing"
+)
+
+func TestHexPointsGeometry(T *testing.T) {
+	var (
+		c      = hexcoords.Hex{3, -2}
+		hex    = hfield.GetHex(c)
+		center = hfield.TileCenter(c)
+		r      = hfield.radius
+		side   = 2 * r * math.Tan(math.Pi/6)
+	)
+	if !hex.Centroid().ApproxEqual(center) {
+		T.Errorf("centroid %v is not the tile center %v", hex.Centroid(), center)
+	}
+	if area := 1.5 * math.Sqrt(3) * side * side; math.Abs(hex.Area()-area) > 1e-6 {
+		T.Errorf("area %g is not %g", hex.Area(), area)
+	}
+	if !hex.Contains(center) {
+		T.Errorf("hexagon does not contain its center")
+	}
+	if !hex.Contains(hex[2]) {
+		T.Errorf("hexagon does not contain its corner")
+	}
+	if hex.Contains(center.Add(point.Point{0, 1.01 * r})) {
+		T.Errorf("hexagon contains a point beyond its north edge")
+	}
+	var b = hex.Bounds()
+	if math.Abs(b.Height()-2*r) > 1e-9 {
+		T.Errorf("bounds height %g is not %g", b.Height(), 2*r)
+	}
+}
+
+func TestGridBounds(T *testing.T) {
+	var b = hfield.Bounds()
+	for u := hfield.ColMin(); u <= hfield.ColMax(); u++ {
+		for v := hfield.RowMin(); v <= hfield.RowMax(); v++ {
+			for _, p := range hfield.GetHex(hexcoords.Hex{u, v}) {
+				if !b.Inset(-1e-9).Contains(p) {
+					T.Fatalf("grid bounds %v do not contain %v", b, p)
+				}
+			}
+		}
+	}
+}
@@ -47,6 +47,42 @@ type Edge struct {
 }
 type EdgeInitializer func(coords hexcoords.Edge, v1, v2 *Vertex) Value
 
+/* Compute initial values from the *Default arguments of NewGrid. */
+func initialTileValue(defaultValue interface{}, c hexcoords.Hex) Value {
+	switch f := defaultValue.(type) {
+	case TileInitializer:
+		return f(c)
+	case func(hexcoords.Hex) Value:
+		return f(c)
+	}
+	return defaultValue
+}
+func initialVertexValue(defaultValue interface{}, vc hexcoords.Vertex) Value {
+	switch f := defaultValue.(type) {
+	case VertexInitializer:
+		return f(vc)
+	case func(hexcoords.Vertex) Value:
+		return f(vc)
+	}
+	return defaultValue
+}
+func initialEdgeValue(defaultValue interface{}, e hexcoords.Edge, v1, v2 *Vertex) Value {
+	switch f := defaultValue.(type) {
+	case EdgeInitializer:
+		return f(e, v1, v2)
+	case func(hexcoords.Edge, *Vertex, *Vertex) Value:
+		return f(e, v1, v2)
+	}
+	return defaultValue
+}
+func isEdgeInitializer(defaultValue interface{}) bool {
+	switch defaultValue.(type) {
+	case EdgeInitializer, func(hexcoords.Edge, *Vertex, *Vertex) Value:
+		return true
+	}
+	return false
+}
+
 //  A grid of hexagons in a discrete coordinate system (u,v) where u
 //  indexes the column of the grid, and v the row.
 type Grid struct {
@@ -58,25 +94,63 @@ type Grid struct {
 	e        []Edge
 	t        []Tile
 	hexes    [][]*HexPoints
-	tiles    [][]*Tile
-	vertices [][][]*Vertex
-	edges    [][][][]*Edge
+	// Indices into v and e, by vertex and edge slot (see storage.go).
+	vertexIndex []int32
+	edgeIndex   []int32
+	compact     *compactStorage
+	events      *events
+	topology    Topology
 }
 
 //  Create an nxm grid of hexagons with radius r. Where n is the number of
-//  columns and m is the number of rows. The integers n and m must be odd.
+//  columns and m is the number of rows. The integers n and m must be odd
+//  (see NewWrappedGrid for grids with wrapping borders).
 //  The *Default arguments dictate the initialized Value field of each Tile,
 //  Vertex and Edge object. If the value of a default is a function taking
 //  the proper arguments and returning a Value object then that function is
 //  called to generate each objects initial value. See also, TileInitializer,
 //  VertexInitializer, and EdgeInitializer.
 func NewGrid(n, m int, r float64, tileDefault, vertexDefault, edgeDefault interface{}) *Grid {
-	if n&1 == 0 || m&1 == 0 {
-		panic("evensize")
-	}
-	if n < 0 || n < 0 {
+	var h = newGrid(n, m, r)
+	h.genHexagons()
+	h.genTiles(tileDefault)
+	h.genVertices(vertexDefault)
+	h.genEdges(edgeDefault) // Must come after genVertices.
+	return h
+}
+
+//  Create an nxm grid like NewGrid that stores only the values of its
+//  tiles, vertices and edges, in flat slices indexed by canonical
+//  coordinates. Positions and hexagons are computed on demand from
+//  TileCenter. A compact grid uses a fraction of the memory of a grid
+//  returned by NewGrid, making very large maps practical.
+//
+//  The Tile, Vertex and Edge objects returned by a compact grid are built
+//  on demand and hold a copy of the stored value; assigning to their Value
+//  field has no effect on the grid. Use SetTileValue, SetVertexValue and
+//  SetEdgeValue to modify a compact grid.
+func NewCompactGrid(n, m int, r float64, tileDefault, vertexDefault, edgeDefault interface{}) *Grid {
+	var h = newGrid(n, m, r)
+	h.genCompact(tileDefault, vertexDefault, edgeDefault)
+	return h
+}
+
+func newGrid(n, m int, r float64) *Grid {
+	return newGridTopology(n, m, r, Bounded)
+}
+
+func newGridTopology(n, m int, r float64, topology Topology) *Grid {
+	if n < 0 || m < 0 {
 		panic("negsize")
 	}
+	switch {
+	case topology == Bounded && (n&1 == 0 || m&1 == 0):
+		panic("evensize")
+	case topology&WrapHorizontal != 0 && n&1 == 1:
+		panic("oddsize")
+	case topology&^WrapBoth != 0:
+		panic("topology")
+	}
 	if r < 0 {
 		panic("negradius")
 	}
@@ -84,43 +158,61 @@ func NewGrid(n, m int, r float64, tileDefault, vertexDefault, edgeDefault interf
 	h.radius = r
 	h.n = n
 	h.m = m
-	h.genHexagons()
-	h.genTiles(tileDefault)
-	h.genVertices(vertexDefault)
-	h.genEdges(edgeDefault) // Must come after genVertices.
+	h.topology = topology
+	h.events = new(events)
 	return h
 }
 
+//  Returns true if h was created by NewCompactGrid.
+func (h *Grid) IsCompact() bool {
+	return h.compact != nil
+}
+
 //  Retrieve a Tile object specified by its coordinates.
 func (h *Grid) GetTile(c hexcoords.Hex) *Tile {
-	if !h.WithinBounds(c) {
+	c, ok := h.Wrap(c)
+	if !ok {
 		return nil
 	}
-	i, j := h.hexIndex(c)
-	return h.tiles[i][j]
+	if h.compact != nil {
+		return &Tile{Hex: c, Pos: h.TileCenter(c), Value: h.compact.tiles[h.tileSlot(c)]}
+	}
+	return &h.t[h.tileSlot(c)]
 }
 
 //  Retrieve a Vertex object specified by its coordinates.
 func (h *Grid) GetVertex(vert hexcoords.Vertex) *Vertex {
-	var inbounds = h.getVCWithinBounds(vert)
-	if !h.WithinBounds(inbounds.Hex()) {
+	if h.compact != nil {
+		return h.compactVertex(h.compact, vert)
+	}
+	var slot, ok = h.vertexSlot(vert)
+	if !ok {
 		return nil
 	}
-	i, j := h.hexIndex(inbounds.Hex())
-	return h.vertices[i][j][inbounds.K]
+	return &h.v[h.vertexIndex[slot]]
 }
 
 //  Retrieve an Edge object specified by its coordinates.
 func (h *Grid) GetEdge(e hexcoords.Edge) *Edge {
-	var c = e.Hex()
-	if !h.WithinBounds(c) {
+	if h.compact != nil {
+		var slot, ok = h.edgeSlot(e)
+		if !ok {
+			return nil
+		}
+		return &Edge{Hex: h.canonicalEdge(e), Value: h.compact.edges[slot]}
+	}
+	var slot, ok = h.edgeSlot(e)
+	if !ok {
 		return nil
 	}
-	i, j := h.hexIndex(c)
-	return h.edges[i][j][e.K][e.L]
+	return &h.e[h.edgeIndex[slot]]
 }
+
+//  The edges of the tile at coords, indexed by hex.Edge. Returns nil if
+//  coords is not within the bounds of h.
 func (h *Grid) GetEdges(coords hexcoords.Hex) []*Edge {
-	if !h.WithinBounds(coords) {
+	coords, ok := h.Wrap(coords)
+	if !ok {
 		return nil
 	}
 	var edges = make([]*Edge, 6)
@@ -130,6 +222,122 @@ func (h *Grid) GetEdges(coords hexcoords.Hex) []*Edge {
 	return edges
 }
 
+//  The value of the tile at c. Returns nil if c is not within the bounds of
+//  h.
+func (h *Grid) TileValue(c hexcoords.Hex) Value {
+	c, ok := h.Wrap(c)
+	if !ok {
+		return nil
+	}
+	if h.compact != nil {
+		return h.compact.tiles[h.tileSlot(c)]
+	}
+	return h.GetTile(c).Value
+}
+
+//  Set the value of the tile at c. Panics if c is not within the bounds of
+//  h.
+func (h *Grid) SetTileValue(c hexcoords.Hex, value Value) {
+	defer h.deliver()
+	h.setTileValue(c, value)
+}
+
+//  Set the value of the tile at c without calling observers.
+func (h *Grid) setTileValue(c hexcoords.Hex, value Value) {
+	c, ok := h.Wrap(c)
+	if !ok {
+		panic("outofbounds")
+	}
+	h.applyChange(Change{Kind: TileChange, Hex: c, Old: h.TileValue(c), New: value})
+}
+
+//  The value of the vertex vc. Returns nil if vc is not a vertex of h.
+func (h *Grid) VertexValue(vc hexcoords.Vertex) Value {
+	if h.compact != nil {
+		var slot, ok = h.vertexSlot(vc)
+		if !ok {
+			return nil
+		}
+		return h.compact.vertices[slot]
+	}
+	var vert = h.GetVertex(vc)
+	if vert == nil {
+		return nil
+	}
+	return vert.Value
+}
+
+//  Set the value of the vertex vc. Panics if vc is not a vertex of h.
+func (h *Grid) SetVertexValue(vc hexcoords.Vertex, value Value) {
+	defer h.deliver()
+	h.setVertexValue(vc, value)
+}
+
+//  Set the value of the vertex vc without calling observers.
+func (h *Grid) setVertexValue(vc hexcoords.Vertex, value Value) {
+	if !h.hasVertex(vc) {
+		panic("outofbounds")
+	}
+	h.applyChange(Change{Kind: VertexChange, Vertex: h.canonicalVertex(vc), Old: h.VertexValue(vc), New: value})
+}
+
+//  The value of the edge e. Returns nil if e is not an edge of h.
+func (h *Grid) EdgeValue(e hexcoords.Edge) Value {
+	if h.compact != nil {
+		var slot, ok = h.edgeSlot(e)
+		if !ok {
+			return nil
+		}
+		return h.compact.edges[slot]
+	}
+	var edge = h.GetEdge(e)
+	if edge == nil {
+		return nil
+	}
+	return edge.Value
+}
+
+//  Set the value of the edge e. Panics if e is not an edge of h.
+func (h *Grid) SetEdgeValue(e hexcoords.Edge, value Value) {
+	defer h.deliver()
+	h.setEdgeValue(e, value)
+}
+
+//  Set the value of the edge e without calling observers.
+func (h *Grid) setEdgeValue(e hexcoords.Edge, value Value) {
+	if _, ok := h.edgeSlot(e); !ok {
+		panic("outofbounds")
+	}
+	h.applyChange(Change{Kind: EdgeChange, Edge: h.canonicalEdge(e), Old: h.EdgeValue(e), New: value})
+}
+
+//  Store the New value of ch in h. The coordinates of ch must be valid.
+func (h *Grid) storeValue(ch Change) {
+	switch ch.Kind {
+	case TileChange:
+		var slot = h.tileSlot(ch.Hex)
+		if h.compact != nil {
+			h.compact.tiles[slot] = ch.New
+		} else {
+			h.t[slot].Value = ch.New
+		}
+	case VertexChange:
+		var slot, _ = h.vertexSlot(ch.Vertex)
+		if h.compact != nil {
+			h.compact.vertices[slot] = ch.New
+		} else {
+			h.v[h.vertexIndex[slot]].Value = ch.New
+		}
+	case EdgeChange:
+		var slot, _ = h.edgeSlot(ch.Edge)
+		if h.compact != nil {
+			h.compact.edges[slot] = ch.New
+		} else {
+			h.e[h.edgeIndex[slot]].Value = ch.New
+		}
+	}
+}
+
 //  Returns the width and height of the Grid wrapped in a
 //  GridDimensions object.
 func (h *Grid) Size() hexcoords.Hex {
@@ -138,15 +346,31 @@ func (h *Grid) Size() hexcoords.Hex {
 
 //  Total number of distinct hexagon vertices in the field.
 func (h *Grid) expectedNumVertices() int {
+	if h.topology != Bounded {
+		var count int
+		h.eachVertexSlot(func(hexcoords.Vertex, int) { count++ })
+		return count
+	}
 	return 2 * (h.n*h.m + h.n + h.m)
 }
 func (h *Grid) NumVertices() int {
+	if h.compact != nil {
+		return h.expectedNumVertices()
+	}
 	return len(h.v)
 }
 func (h *Grid) expectedNumEdges() int {
+	if h.topology != Bounded {
+		var count int
+		h.eachEdgeSlot(func(hexcoords.Edge, int) { count++ })
+		return count
+	}
 	return 3*h.n*h.m + 2*h.n + 2*h.m - 1
 }
 func (h *Grid) NumEdges() int {
+	if h.compact != nil {
+		return h.expectedNumEdges()
+	}
 	return len(h.e)
 }
 func (h *Grid) expectedNumTiles() int {
@@ -155,6 +379,9 @@ func (h *Grid) expectedNumTiles() int {
 
 //  Number of hex tiles in the field (n^2).
 func (h *Grid) NumTiles() int {
+	if h.compact != nil {
+		return h.expectedNumTiles()
+	}
 	return len(h.t)
 }
 func (h *Grid) NumCols() int {
@@ -163,24 +390,41 @@ func (h *Grid) NumCols() int {
 func (h *Grid) NumRows() int {
 	return h.m
 }
+
+//  The position of the tile at c in per-tile arrays such as
+//  FlowField.Directions. Tiles are numbered column by column, starting
+//  from (ColMin, RowMin). The second return value is false if c is not a
+//  tile of h.
+func (h *Grid) TileIndex(c hexcoords.Hex) (int, bool) {
+	c, ok := h.Wrap(c)
+	if !ok {
+		return 0, false
+	}
+	return h.tileSlot(c), true
+}
+
+//  The radius (apothem) of the tiles of h, as given to NewGrid.
+func (h *Grid) Radius() float64 {
+	return h.radius
+}
 func (h *Grid) horizontalIndexOffset() int {
-	return int(math.Floor(float64(h.NumCols()) / 2))
+	return h.n / 2
 }
 func (h *Grid) verticalIndexOffset() int {
-	return int(math.Floor(float64(h.NumRows()) / 2))
+	return h.m / 2
 }
 
 //  Minimum value of the row coordinate v.
 func (h *Grid) RowMin() int { return -h.verticalIndexOffset() }
 
 //  Maximum value of the row coordinate v.
-func (h *Grid) RowMax() int { return h.verticalIndexOffset() }
+func (h *Grid) RowMax() int { return h.RowMin() + h.m - 1 }
 
 //  Minimum value of the column coordinate u.
 func (h *Grid) ColMin() int { return -h.horizontalIndexOffset() }
 
 //  Maximum value of the column coordinate u.
-func (h *Grid) ColMax() int { return h.horizontalIndexOffset() }
+func (h *Grid) ColMax() int { return h.ColMin() + h.n - 1 }
 
 /* Some coordinate <-> index internal methods. */
 func (h *Grid) hexCoords(i, j int) hexcoords.Hex {
@@ -197,24 +441,41 @@ func (h *Grid) indexWithinBounds(i, j int) bool {
 
 //  Returns true if the hex at coordinates (u,v) is in the hex field.
 func (h *Grid) WithinBounds(c hexcoords.Hex) bool {
-	if c.U < h.ColMin() || c.U > h.ColMax() {
-		return false
-	} else if c.V < h.RowMin() || c.V > h.RowMax() {
-		return false
+	var i, j = h.hexIndex(c)
+	return 0 <= i && i < h.n && 0 <= j && j < h.m
+}
+
+//  The spreadsheet-style name of tile c (see hexcoords.FormatA1). Column A
+//  is ColMin and row 1 is RowMin. Returns the empty string if c is not
+//  within the bounds of h.
+func (h *Grid) A1(c hexcoords.Hex) string {
+	if !h.WithinBounds(c) {
+		return ""
+	}
+	return hexcoords.FormatA1(c, hexcoords.Hex{h.ColMin(), h.RowMin()})
+}
+
+//  The coordinates of the tile named s by A1. Returns an error if s is
+//  malformed or names a tile outside the bounds of h.
+func (h *Grid) ParseA1(s string) (hexcoords.Hex, error) {
+	var c, err = hexcoords.ParseA1(s, hexcoords.Hex{h.ColMin(), h.RowMin()})
+	if err == nil && !h.WithinBounds(c) {
+		return hexcoords.Hex{}, fmt.Errorf("hexgrid: tile %s outside the grid", s)
 	}
-	return true
+	return c, err
 }
 
 //  Generate points for the hexagon at row i, column j.
 //  Returns nil when the position (i,j) is not within the bounds of the board.
 func (h *Grid) GetHex(c hexcoords.Hex) *HexPoints {
-	if !h.WithinBounds(c) {
+	c, ok := h.Wrap(c)
+	if !ok {
 		return nil
 	}
 	var (
 		i, j = h.hexIndex(c)
 	)
-	if h.hexes[i][j] != nil {
+	if h.hexes != nil && h.hexes[i][j] != nil {
 		var newh = new(HexPoints)
 		*newh = *(h.hexes[i][j])
 		return newh
@@ -286,6 +547,17 @@ func (hex *HexPoints) EdgePoints(dir hex.Direction) []point.Point {
 	return []point.Point{p1, p2}
 }
 
+//  Returns a new hexagon with each corner of hex transformed by a. The
+//  result need not be regular when a contains scaling or shear (e.g.
+//  for an isometric view).
+func (hex *HexPoints) Transform(a point.Affine) *HexPoints {
+	var newh = new(HexPoints)
+	for k, p := range hex {
+		newh[k] = a.Apply(p)
+	}
+	return newh
+}
+
 //  Generate a hexagon at a given point.
 func NewHex(p point.Point, r float64) *HexPoints {
 	var (
@@ -302,14 +574,12 @@ func NewHex(p point.Point, r float64) *HexPoints {
 	return h
 }
 
+//  A vertex identical to vc whose tile is within the bounds of h, found
+//  across wrapping borders. Returns vc if there is none.
 func (h *Grid) getVCWithinBounds(vc hexcoords.Vertex) hexcoords.Vertex {
-	if h.WithinBounds(vc.Hex()) {
-		return vc
-	}
-	var idents = vc.IdenticalVertices()
-	for _, id := range idents[1:] {
-		if h.WithinBounds(id.Hex()) {
-			return id
+	for _, id := range vc.IdenticalVertices() {
+		if c, ok := h.Wrap(id.Hex()); ok {
+			return hexcoords.Vertex{c.U, c.V, id.K}
 		}
 	}
 	return vc
@@ -320,16 +590,20 @@ func (h *Grid) getVCWithinBounds(vc hexcoords.Vertex) hexcoords.Vertex {
 //  bounds of h.
 func (h *Grid) GetVertexPoint(vc hexcoords.Vertex) point.Point {
 	var inbounds = h.getVCWithinBounds(vc)
-	var hex = h.GetHex(inbounds.Hex())
-	if hex == nil {
+	if !h.WithinBounds(inbounds.Hex()) {
 		return point.Inf()
 	}
-	return hex[inbounds.K]
+	if h.hexes != nil {
+		var i, j = h.hexIndex(inbounds.Hex())
+		return h.hexes[i][j][inbounds.K]
+	}
+	return h.vertexPos(inbounds)
 }
 
 //  This methods should be replaced.
 func (h *Grid) GetVertices(coords hexcoords.Hex) []*Vertex {
-	if !h.WithinBounds(coords) {
+	coords, ok := h.Wrap(coords)
+	if !ok {
 		return nil
 	}
 	var vertices = make([]*Vertex, 6)
@@ -363,169 +637,60 @@ func (h *Grid) TileCenter(c hexcoords.Hex) point.Point {
 }
 
 func (h *Grid) genTiles(defaultValue Value) {
+	// Tiles are stored in the order of tileSlot.
 	h.t = make([]Tile, 0, h.expectedNumTiles())
-	// Generate all tiles.
-	h.tiles = make([][]*Tile, h.n)
 	for i := 0; i < h.n; i++ {
-		h.tiles[i] = make([]*Tile, h.m)
 		for j := 0; j < h.m; j++ {
 			var (
 				coords = h.hexCoords(i, j)
 				center = h.TileCenter(coords)
-				value  Value
+				value  = initialTileValue(defaultValue, coords)
 			)
-			switch defaultValue.(type) {
-			case func(hexcoords.Hex) Value:
-				var f = defaultValue.(func(hexcoords.Hex) Value)
-				value = f(coords)
-			default:
-				value = defaultValue
-			}
 			h.t = append(h.t, Tile{Hex: coords, Pos: center, Value: value})
-			h.tiles[i][j] = &(h.t[len(h.t)-1])
 		}
 	}
 }
 func (h *Grid) genVertices(defaultValue Value) {
-	// Make space for vertices/pointers.
 	h.v = make([]Vertex, 0, h.expectedNumVertices())
-	h.vertices = make([][][]*Vertex, h.n)
-	for i := 0; i < h.n; i++ {
-		h.vertices[i] = make([][]*Vertex, h.m)
-		for j := 0; j < h.m; j++ {
-			h.vertices[i][j] = make([]*Vertex, 6)
-		}
-	}
-	// Generate vertices
-	for i := 0; i < h.n; i++ {
-		for j := 0; j < h.m; j++ {
-			var (
-				c   = h.hexCoords(i, j)
-				hex = h.GetHex(c)
-			)
-			for k := 0; k < 6; k++ {
-				if h.vertices[i][j][k] == nil {
-					var (
-						vert          = hexcoords.Vertex{c.U, c.V, k}
-						identVertices = vert.IdenticalVertices()
-						coords        = vert
-						value         Value
-					)
-					if identVertices == nil {
-						panic("outofbounds")
-					}
-					switch defaultValue.(type) {
-					case func(hexcoords.Vertex) Value:
-						var f = defaultValue.(func(hexcoords.Vertex) Value)
-						value = f(coords)
-					default:
-						value = defaultValue
-					}
-					h.v = append(h.v, Vertex{Hex: coords, Pos: hex[k], Value: value})
-					for _, ident := range identVertices {
-						var (
-							c              = ident.Hex()
-							iIdent, jIdent = h.hexIndex(c)
-						)
-						if h.WithinBounds(c) {
-							h.vertices[iIdent][jIdent][ident.K] = &(h.v[len(h.v)-1])
-						}
-					}
-				}
-			}
-		}
-	}
+	h.vertexIndex = newSlotIndex(h.numVertexSlots())
+	h.eachVertexSlot(func(coords hexcoords.Vertex, slot int) {
+		var value = initialVertexValue(defaultValue, coords)
+		h.vertexIndex[slot] = int32(len(h.v))
+		h.v = append(h.v, Vertex{Hex: coords, Pos: h.GetVertexPoint(coords), Value: value})
+	})
 }
 func (h *Grid) genEdges(defaultValue Value) {
-	// Make space for edges/pointers.
 	h.e = make([]Edge, 0, h.expectedNumEdges())
-	h.edges = make([][][][]*Edge, h.n)
-	for i := 0; i < h.n; i++ {
-		h.edges[i] = make([][][]*Edge, h.m)
-		for j := 0; j < h.m; j++ {
-			h.edges[i][j] = make([][]*Edge, 6)
-			for k := 0; k < 6; k++ {
-				h.edges[i][j][k] = make([]*Edge, 6)
-			}
-		}
-	}
-	// Generate all edges.
-	for i := 0; i < h.n; i++ {
-		for j := 0; j < h.m; j++ { // BEGIN (u,v) TILE ANALYSIS
-			var (
-				c = h.hexCoords(i, j)
-			)
-			for k := 0; k < 6; k++ {
-				for ell := 0; ell < 6; ell++ { // BEGIN (k,ell) EDGE ANALYSIS
-					// Ensure an edge between k and ell exists.
-					var edgeDir = EdgeDirection(k, ell)
-					if edgeDir != hex.NilDirection && h.edges[i][j][k][ell] == nil {
-						var (
-							coords = hexcoords.Edge{c.U, c.V, k, ell}
-							value  Value
-							v1     = h.vertices[i][j][k]
-							v2     = h.vertices[i][j][ell]
-						)
-						switch defaultValue.(type) {
-						case func(hexcoords.Edge, *Vertex, *Vertex) Value:
-							var f = defaultValue.(func(hexcoords.Edge, *Vertex, *Vertex) Value)
-							value = f(coords, v1, v2)
-						default:
-							value = defaultValue
-						}
-						// Create the edge, compute the other incident tile.
-						h.e = append(h.e, Edge{Hex: coords, Value: value})
-						var (
-							edgePtr        = &(h.e[len(h.e)-1])
-							adjEdgeIndices = HexEdgeIndices(edgeDir.Inverse())
-						)
-						if adjEdgeIndices == nil {
-							panic("niladjindices")
-						}
-						var (
-							adjK   = adjEdgeIndices[0]
-							adjEll = adjEdgeIndices[1]
-						)
-						if edgeDir == hex.NilDirection {
-							panic("niledgedirection")
-						}
-						var adjHexSlice = c.Adjacents(edgeDir)
-						if adjHexSlice == nil {
-							panic("niladjcoords")
-						}
-						// Store the edge pointer is its various configurations.
-						var (
-							adjHex = adjHexSlice[0]
-							//adjU       = adjHex.U
-							//adjV       = adjHex.V
-							adjI, adjJ = h.hexIndex(adjHex)
-						)
-						if h.WithinBounds(adjHex) {
-							h.edges[adjI][adjJ][adjK][adjEll] = edgePtr
-							h.edges[adjI][adjJ][adjEll][adjK] = edgePtr
-						}
-						h.edges[i][j][k][ell] = edgePtr
-						h.edges[i][j][ell][k] = edgePtr
-					}
-				} // END (k,ell) EDGE ANALYSIS
-			}
-		} // END (u,v) TILE ANALYSIS
-	}
+	h.edgeIndex = newSlotIndex(h.numEdgeSlots())
+	h.eachEdgeSlot(func(coords hexcoords.Edge, slot int) {
+		var (
+			vc1, vc2 = coords.Ends()
+			value    = initialEdgeValue(defaultValue, coords, h.GetVertex(vc1), h.GetVertex(vc2))
+		)
+		h.edgeIndex[slot] = int32(len(h.e))
+		h.e = append(h.e, Edge{Hex: coords, Value: value})
+	})
 }
 func (h *Grid) genHexagons() {
 	h.p = make([]point.Point, 0, h.expectedNumVertices())
 	h.hexes = make([][]*HexPoints, h.n)
-	// Generate all hexagons.
+	// Generate all hexagons in one block of memory.
+	var (
+		flat    = make([]HexPoints, h.n*h.m)
+		corners = NewHex(point.Zero(), h.radius)
+	)
 	for i := 0; i < h.n; i++ {
 		h.hexes[i] = make([]*HexPoints, h.m)
 		for j := 0; j < h.m; j++ {
 			var (
-				c = h.hexCoords(i, j)
+				c      = h.hexCoords(i, j)
+				center = h.TileCenter(c)
+				hex    = &flat[i*h.m+j]
 			)
-			h.hexes[i][j] = h.GetHex(c)
-			if h.hexes[i][j] == nil {
-				panic(fmt.Sprintf("OutOfBounds(%d,%d)", i, j))
+			for k := range hex {
+				hex[k] = corners[k].Add(center)
 			}
+			h.hexes[i][j] = hex
 		}
 	}
 
@@ -540,24 +705,13 @@ func (h *Grid) genHexagons() {
 			for k := 0; k < 6; k++ {
 				var idents = hexcoords.Vertex{c.U, c.V, k}.IdenticalVertices()
 				for _, id := range idents[1:] {
-					var joinVertices = func() {
-						var (
-							adjHex = h.GetHex(id.Hex())
-						)
-						if adjHex == nil {
-							return
-						}
-						hex[k] = adjHex[id.K]
-						//log.Printf("Joined (%d %d %d) and (%d %d %d)", u, v, k, id.U, id.V, id.K)
-						toAdd[k] = false
-					}
-					if id.V < c.V {
-						joinVertices()
-					} else if id.V == c.V {
-						if id.U < c.U {
-							joinVertices()
-						}
+					var earlier = id.V < c.V || (id.V == c.V && id.U < c.U)
+					if !earlier || !h.WithinBounds(id.Hex()) {
+						continue
 					}
+					var iAdj, jAdj = h.hexIndex(id.Hex())
+					hex[k] = h.hexes[iAdj][jAdj][id.K]
+					toAdd[k] = false
 				}
 			}
 
@@ -6,6 +6,7 @@ Created: Tue Jun 28 17:55:54 PDT 2011
 package hexgrid
 
 import (
+	"github.com/bmatsuo/hexgrid/hex"
 	"github.com/bmatsuo/hexgrid/point"
 	"github.com/bmatsuo/hexgrid/hexcoords"
 
@@ -167,3 +168,58 @@ func TestGridNumEdges(T *testing.T) {
     )
     testAllocation(expected, length, capacity, T)
 }
+
+func BenchmarkNewGrid(B *testing.B) {
+    B.ReportAllocs()
+    for i := 0; i < B.N; i++ {
+        NewGrid(101, 101, 1, nil, nil, nil)
+    }
+}
+
+func BenchmarkNewCompactGrid(B *testing.B) {
+    B.ReportAllocs()
+    for i := 0; i < B.N; i++ {
+        NewCompactGrid(101, 101, 1, nil, nil, nil)
+    }
+}
+
+func BenchmarkGetEdge(B *testing.B) {
+    var (
+        h     = NewGrid(101, 101, 1, nil, nil, nil)
+        edges = hexcoords.Hex{3, 4}.Edges(hex.NilDirection)
+    )
+    B.ResetTimer()
+    for i := 0; i < B.N; i++ {
+        h.GetEdge(edges[i%6])
+    }
+}
+
+func BenchmarkGetVertex(B *testing.B) {
+    var (
+        h        = NewGrid(101, 101, 1, nil, nil, nil)
+        vertices = hexcoords.Hex{3, 4}.Vertices(hex.NilDirection)
+    )
+    B.ResetTimer()
+    for i := 0; i < B.N; i++ {
+        h.GetVertex(vertices[i%6])
+    }
+}
+
+func TestGridA1(T *testing.T) {
+    var h = NewGrid(5, 7, 1, nil, nil, nil)
+    if s := h.A1(hexcoords.Hex{h.ColMin(), h.RowMin()}); s != "A1" {
+        T.Errorf("corner named %q", s)
+    }
+    if s := h.A1(hexcoords.Hex{h.ColMax(), h.RowMax()}); s != "E7" {
+        T.Errorf("opposite corner named %q", s)
+    }
+    if c, err := h.ParseA1("C7"); err != nil || c != (hexcoords.Hex{0, h.RowMax()}) {
+        T.Errorf("C7 parsed %v %v", c, err)
+    }
+    if _, err := h.ParseA1("F1"); err == nil {
+        T.Errorf("F1 parsed outside the grid")
+    }
+    if h.A1(hexcoords.Hex{h.ColMax() + 1, 0}) != "" {
+        T.Errorf("out of bounds tile named")
+    }
+}
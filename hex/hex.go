@@ -101,11 +101,11 @@ var vertexDirection = []Direction{
 }
 
 func (v Vertex) Clockwise() Vertex {
-	return (v + 1) % _V_INVALID
+	return (v + 5) % _V_INVALID
 }
 
 func (v Vertex) CounterClockwise() Vertex {
-	return (v + 5) % _V_INVALID
+	return (v + 1) % _V_INVALID
 }
 
 func (v Vertex) Direction() Direction {
@@ -143,13 +143,13 @@ func (dir Direction) Edge() Edge {
 }
 
 const (
-	E_S  = Edge(V_SW)
-	E_SE = Edge(V_SE)
-	E_NE = Edge(V_E)
-	E_N  = Edge(V_NE)
-	E_NW = Edge(V_NW)
-	E_SW = Edge(V_W)
-	_E_INVALID
+	E_S        = Edge(V_SW)
+	E_SE       = Edge(V_SE)
+	E_NE       = Edge(V_E)
+	E_N        = Edge(V_NE)
+	E_NW       = Edge(V_NW)
+	E_SW       = Edge(V_W)
+	_E_INVALID = Edge(_V_INVALID)
 )
 
 var edgeDirection = []Direction{
@@ -0,0 +1,45 @@
+/*
+File: hex_test.go
+Created: Mon Oct 19 08:31:07 UTC 2026
+*/
+
+package hex
+
+import (
+	"testing"
+)
+
+func TestVertexRotation(T *testing.T) {
+	if v := V_SW.Clockwise(); v != V_W {
+		T.Errorf("clockwise of SW is %v", v.Direction())
+	}
+	if v := V_SW.CounterClockwise(); v != V_SE {
+		T.Errorf("counter-clockwise of SW is %v", v.Direction())
+	}
+	for v := V_SW; v < _V_INVALID; v++ {
+		if int(v.Clockwise()) != VertexIndexClockwise(int(v)) {
+			T.Errorf("vertex %d: Clockwise disagrees with VertexIndexClockwise", v)
+		}
+		if int(v.CounterClockwise()) != VertexIndexCounterClockwise(int(v)) {
+			T.Errorf("vertex %d: CounterClockwise disagrees with VertexIndexCounterClockwise", v)
+		}
+		if v.Clockwise().CounterClockwise() != v {
+			T.Errorf("vertex %d: rotations are not inverse", v)
+		}
+	}
+}
+
+func TestEdgeEnds(T *testing.T) {
+	for _, dir := range EdgeDirections() {
+		var e = dir.Edge()
+		if e.Direction() != dir {
+			T.Errorf("edge %d has direction %d, expected %d", e, e.Direction(), dir)
+		}
+		if e.Orig() != Vertex(e) || e.Term() != Vertex((e+1)%6) {
+			T.Errorf("edge %d runs from %d to %d", e, e.Orig(), e.Term())
+		}
+	}
+	if E_SW == _E_INVALID || _E_INVALID.Direction() != NilDirection {
+		T.Errorf("invalid edge has direction %d", _E_INVALID.Direction())
+	}
+}
@@ -0,0 +1,218 @@
+/*
+File: format.go
+Created: Mon Oct 19 03:20:14 UTC 2026
+*/
+
+package hexcoords
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+/*
+Text formats.
+
+A Hex is written "(u,v)", a Vertex "(u,v)#k" and an Edge "(u,v)#k-l", for
+example "(3,-2)", "(3,-2)#4" and "(3,-2)#4-5". Parsing accepts spaces around
+the numbers.
+
+For grids addressed like a spreadsheet, FormatA1 and ParseA1 write a Hex as
+column letters followed by a row number ("C7"), counting from a given
+minimum corner.
+*/
+
+var (
+	ErrSyntax = errors.New("hexcoords: invalid syntax")
+	ErrRange  = errors.New("hexcoords: corner index out of range")
+)
+
+func (c Hex) String() string {
+	return "(" + strconv.Itoa(c.U) + "," + strconv.Itoa(c.V) + ")"
+}
+
+func (vc Vertex) String() string {
+	return vc.Hex().String() + "#" + strconv.Itoa(vc.K)
+}
+
+func (e Edge) String() string {
+	return e.Hex().String() + "#" + strconv.Itoa(e.K) + "-" + strconv.Itoa(e.L)
+}
+
+//  Parse a Hex written as by Hex.String. The second return value is the
+//  unparsed remainder of s.
+func parseHexPrefix(s string) (Hex, string, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(") {
+		return Hex{}, s, ErrSyntax
+	}
+	var end = strings.IndexByte(s, ')')
+	if end < 0 {
+		return Hex{}, s, ErrSyntax
+	}
+	var parts = strings.Split(s[1:end], ",")
+	if len(parts) != 2 {
+		return Hex{}, s, ErrSyntax
+	}
+	var u, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Hex{}, s, ErrSyntax
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Hex{}, s, ErrSyntax
+	}
+	return Hex{u, v}, s[end+1:], nil
+}
+
+//  Parse a corner index in [0,6).
+func parseCorner(s string) (int, error) {
+	var k, err = strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, ErrSyntax
+	}
+	if k < 0 || k > 5 {
+		return 0, ErrRange
+	}
+	return k, nil
+}
+
+//  Parse a Hex written as "(u,v)".
+func ParseHex(s string) (Hex, error) {
+	var c, rest, err = parseHexPrefix(s)
+	if err == nil && strings.TrimSpace(rest) != "" {
+		err = ErrSyntax
+	}
+	return c, err
+}
+
+//  Parse a Vertex written as "(u,v)#k".
+func ParseVertex(s string) (Vertex, error) {
+	var c, rest, err = parseHexPrefix(s)
+	if err != nil {
+		return Vertex{}, err
+	}
+	if !strings.HasPrefix(rest, "#") {
+		return Vertex{}, ErrSyntax
+	}
+	k, err := parseCorner(rest[1:])
+	if err != nil {
+		return Vertex{}, err
+	}
+	return Vertex{c.U, c.V, k}, nil
+}
+
+//  Parse an Edge written as "(u,v)#k-l".
+func ParseEdge(s string) (Edge, error) {
+	var c, rest, err = parseHexPrefix(s)
+	if err != nil {
+		return Edge{}, err
+	}
+	if !strings.HasPrefix(rest, "#") {
+		return Edge{}, ErrSyntax
+	}
+	var ends = strings.Split(rest[1:], "-")
+	if len(ends) != 2 {
+		return Edge{}, ErrSyntax
+	}
+	k, err := parseCorner(ends[0])
+	if err != nil {
+		return Edge{}, err
+	}
+	l, err := parseCorner(ends[1])
+	if err != nil {
+		return Edge{}, err
+	}
+	return Edge{c.U, c.V, k, l}, nil
+}
+
+func (c Hex) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Hex) UnmarshalText(text []byte) error {
+	var parsed, err = ParseHex(string(text))
+	if err == nil {
+		*c = parsed
+	}
+	return err
+}
+
+func (vc Vertex) MarshalText() ([]byte, error) {
+	return []byte(vc.String()), nil
+}
+
+func (vc *Vertex) UnmarshalText(text []byte) error {
+	var parsed, err = ParseVertex(string(text))
+	if err == nil {
+		*vc = parsed
+	}
+	return err
+}
+
+func (e Edge) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+func (e *Edge) UnmarshalText(text []byte) error {
+	var parsed, err = ParseEdge(string(text))
+	if err == nil {
+		*e = parsed
+	}
+	return err
+}
+
+//  Write c in spreadsheet notation relative to the corner min: columns are
+//  lettered A through Z, then AA, AB and so on, starting at column min.U,
+//  and rows are numbered from 1 at row min.V. Returns the empty string if c
+//  lies below or left of min.
+func FormatA1(c, min Hex) string {
+	var col, row = c.U - min.U, c.V - min.V + 1
+	if col < 0 || row < 1 {
+		return ""
+	}
+	var letters []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		letters = append([]byte{byte('A' + (col-1)%26)}, letters...)
+	}
+	return string(letters) + strconv.Itoa(row)
+}
+
+//  The most column letters ParseA1 accepts, enough for over 300 million
+//  columns without overflowing an int.
+const maxA1Letters = 6
+
+//  Parse spreadsheet notation written by FormatA1 with the same corner.
+//  Letters may be upper or lower case. The row must be written with digits
+//  only, without a sign.
+func ParseA1(s string, min Hex) (Hex, error) {
+	s = strings.TrimSpace(s)
+	var (
+		i   int
+		col int
+	)
+	for ; i < len(s); i++ {
+		var ch = s[i]
+		if 'a' <= ch && ch <= 'z' {
+			ch -= 'a' - 'A'
+		}
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A') + 1
+	}
+	if i == 0 || i == len(s) || i > maxA1Letters {
+		return Hex{}, ErrSyntax
+	}
+	for _, ch := range s[i:] {
+		if ch < '0' || ch > '9' {
+			return Hex{}, ErrSyntax
+		}
+	}
+	var row, err = strconv.Atoi(s[i:])
+	if err != nil || row < 1 {
+		return Hex{}, ErrSyntax
+	}
+	return Hex{min.U + col - 1, min.V + row - 1}, nil
+}
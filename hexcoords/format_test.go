@@ -0,0 +1,91 @@
+/*
+File: format_test.go
+Created: Mon Oct 19 03:20:14 UTC 2026
+*/
+
+package hexcoords
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFormatRoundTrip(T *testing.T) {
+	var (
+		c  = Hex{3, -2}
+		vc = Vertex{3, -2, 4}
+		e  = Edge{3, -2, 4, 5}
+	)
+	if c.String() != "(3,-2)" || vc.String() != "(3,-2)#4" || e.String() != "(3,-2)#4-5" {
+		T.Errorf("formatted %v %v %v", c, vc, e)
+	}
+	if p, err := ParseHex(" ( 3, -2 ) "); err != nil || p != c {
+		T.Errorf("parsed hex %v %v", p, err)
+	}
+	if p, err := ParseVertex("(3,-2)#4"); err != nil || p != vc {
+		T.Errorf("parsed vertex %v %v", p, err)
+	}
+	if p, err := ParseEdge("(3,-2)#4-5"); err != nil || p != e {
+		T.Errorf("parsed edge %v %v", p, err)
+	}
+	for _, bad := range []string{"", "3,-2", "(3)", "(3,-2", "(3,x)", "(3,-2)x"} {
+		if _, err := ParseHex(bad); err != ErrSyntax {
+			T.Errorf("%q: error %v", bad, err)
+		}
+	}
+	if _, err := ParseVertex("(3,-2)#6"); err != ErrRange {
+		T.Errorf("vertex index 6: error %v", err)
+	}
+	if _, err := ParseEdge("(3,-2)#4"); err != ErrSyntax {
+		T.Errorf("edge with one end: error %v", err)
+	}
+}
+
+func TestTextMarshaling(T *testing.T) {
+	var in = struct {
+		Tiles map[Hex]int
+		Road  Edge
+		Town  Vertex
+	}{map[Hex]int{{1, 2}: 3}, Edge{0, 0, 0, 1}, Vertex{-1, 0, 2}}
+	var data, err = json.Marshal(in)
+	if err != nil {
+		T.Fatal(err)
+	}
+	if string(data) != `{"Tiles":{"(1,2)":3},"Road":"(0,0)#0-1","Town":"(-1,0)#2"}` {
+		T.Errorf("json %s", data)
+	}
+	var out = in
+	out.Tiles = nil
+	if err = json.Unmarshal(data, &out); err != nil {
+		T.Fatal(err)
+	}
+	if out.Tiles[Hex{1, 2}] != 3 || out.Road != in.Road || out.Town != in.Town {
+		T.Errorf("unmarshaled %v", out)
+	}
+}
+
+func TestA1(T *testing.T) {
+	var min = Hex{-3, -3}
+	for _, test := range []struct {
+		c Hex
+		s string
+	}{{Hex{-3, -3}, "A1"}, {Hex{-1, 3}, "C7"}, {Hex{22, 0}, "Z4"}, {Hex{23, 0}, "AA4"}, {Hex{49, 0}, "BA4"}} {
+		if s := FormatA1(test.c, min); s != test.s {
+			T.Errorf("%v formatted %q, expected %q", test.c, s, test.s)
+		}
+		if c, err := ParseA1(test.s, min); err != nil || c != test.c {
+			T.Errorf("%q parsed %v %v", test.s, c, err)
+		}
+	}
+	if c, err := ParseA1("c7", min); err != nil || c != (Hex{-1, 3}) {
+		T.Errorf("lower case parsed %v %v", c, err)
+	}
+	for _, bad := range []string{"", "7", "C", "C0", "C-1", "7C", "A+5", "A-0", "C 7", "ABCDEFG1", "ZZZZZZZZZZZZZZZ1"} {
+		if _, err := ParseA1(bad, min); err == nil {
+			T.Errorf("%q parsed", bad)
+		}
+	}
+	if FormatA1(Hex{-4, 0}, min) != "" {
+		T.Errorf("coordinates left of the corner formatted")
+	}
+}
@@ -8,7 +8,6 @@ package hexcoords
 
 import (
 	"github.com/bmatsuo/hexgrid/hex"
-	"math"
 	//"log"
 )
 
@@ -159,6 +158,28 @@ func (vc Vertex) IsIdentical(other Vertex) bool {
 	}
 	return false
 }
+//  Every vertex is the south-west (K=0) or south-east (K=1) corner of
+//  exactly one tile. The canonical coordinates of vc are those of that
+//  corner. Two Vertex values reference the same vertex if and only if their
+//  canonical coordinates are equal.
+func (vc Vertex) Canonical() Vertex {
+	if vc.K == 0 || vc.K == 1 {
+		return vc
+	}
+	if vc.K < 0 || vc.K > 5 {
+		return vc
+	}
+	var offsets = hexLowVertexIncidenceOffset[vc.K]
+	if ColumnIsHigh(vc.U) {
+		offsets = hexHighVertexIncidenceOffset[vc.K]
+	}
+	for _, offset := range offsets {
+		if offset[2] == 0 || offset[2] == 1 {
+			return Vertex{vc.U + offset[0], vc.V + offset[1], offset[2]}
+		}
+	}
+	return vc
+}
 func (vc Vertex) Clockwise() Vertex {
 	return Vertex{vc.U, vc.V, hex.VertexIndexClockwise(vc.K)}
 }
@@ -194,16 +215,43 @@ func (e Edge) reverse() Edge {
 
 //  Returns true if and only if e and e2 reference the same edge.
 func (e Edge) IsIdentical(e2 Edge) bool {
-	var (
-		adjc  = e.Incidents()
-		adjc2 = e2.Incidents()
-	)
-	if adjc[0].Equals(adjc2[1]) && adjc[1].Equals(adjc[0]) {
-		return true
-	} else if adjc[1].Equals(adjc2[0]) && adjc[0].Equals(adjc[1]) {
-		return true
+	return e.Canonical().Equals(e2.Canonical())
+}
+
+//  The direction of e from the center of its hex tile. Returns
+//  hex.NilDirection if the K and L fields of e are not the ends of a
+//  single hexagon side.
+func (e Edge) Direction() hex.Direction {
+	if e.K < 0 || e.K > 5 || e.L < 0 || e.L > 5 {
+		return hex.NilDirection
 	}
-	return false
+	if e.L == hex.VertexIndexCounterClockwise(e.K) {
+		return hex.Edge(e.K).Direction()
+	} else if e.K == hex.VertexIndexCounterClockwise(e.L) {
+		return hex.Edge(e.L).Direction()
+	}
+	return hex.NilDirection
+}
+
+//  Every edge is a south-facing side (S, SE or SW) of exactly one tile.
+//  The canonical coordinates of e are those of that side, with K and L
+//  ordered as returned by Hex.Edges. Two Edge values reference the same
+//  edge if and only if their canonical coordinates are equal. Returns
+//  NilEdge() if e is not a valid edge.
+func (e Edge) Canonical() Edge {
+	var dir = e.Direction()
+	switch dir {
+	case hex.NilDirection:
+		return nilEdge
+	case hex.N, hex.NE, hex.NW:
+		var (
+			c    = e.Hex().Adjacent(dir)
+			side = dir.Inverse().Edge()
+		)
+		return Edge{c.U, c.V, side.Orig().Int(), side.Term().Int()}
+	}
+	var side = dir.Edge()
+	return Edge{e.U, e.V, side.Orig().Int(), side.Term().Int()}
 }
 
 //	Returns coordinates of edges sharing one endpoint with e.
@@ -241,8 +289,7 @@ func (e Edge) Ends() (v1, v2 Vertex) {
 }
 
 func ColumnIsHigh(u int) bool {
-	var uOdd = uint(math.Abs(float64(u)))%2 == 1
-	return uOdd
+	return u&1 == 1
 }
 func sameTile(u1, v1, u2, v2 int) bool {
 	return Hex{u1, v1}.Equals(Hex{u2, v2})
@@ -251,49 +298,9 @@ func sameTile(u1, v1, u2, v2 int) bool {
 //  If hex tiles (u1,v1) and (u2,v2) are adjacent, the direction of (u2,v2)
 //  from (u1,v1) is returned. Otherwise hex.NilDirection is returned.
 func (c Hex) Adjacency(adj Hex) hex.Direction {
-	var (
-		deltaU = adj.U - c.U
-		deltaV = adj.U - c.U
-	)
-	if c.U == adj.U {
-		if deltaV == 1 {
-			return hex.N
-		} else if deltaV == -1 {
-			return hex.S
-		}
-		return hex.NilDirection
-	}
-	if deltaU == 1 {
-		if ColumnIsHigh(c.U) {
-			if c.U == adj.U {
-				return hex.SE
-			}
-			if adj.U == c.U+1 {
-				return hex.NE
-			}
-		} else {
-			if adj.V == c.V-1 {
-				return hex.SE
-			}
-			if c.V == adj.V {
-				return hex.NE
-			}
-		}
-	} else if deltaU == -1 {
-		if ColumnIsHigh(c.U) {
-			if adj.V == c.V+1 {
-				return hex.NW
-			}
-			if c.V == adj.V {
-				return hex.SW
-			}
-		} else {
-			if c.V == adj.V {
-				return hex.NW
-			}
-			if adj.V == c.V-1 {
-				return hex.SW
-			}
+	for _, dir := range hex.EdgeDirections() {
+		if c.Adjacent(dir).Equals(adj) {
+			return dir
 		}
 	}
 	return hex.NilDirection
@@ -304,82 +311,91 @@ func (c Hex) IsAdjacent(adj Hex) bool {
 	return c.Adjacency(adj) != hex.NilDirection
 }
 
+//  Axial coordinates of c, in which the hexagons adjacent to any hexagon
+//  differ from it by the same six offsets.
+func (c Hex) axial() (q, r int) {
+	return c.U, c.V - c.U>>1
+}
+
+//  The number of steps between adjacent hexagons needed to reach other from
+//  c.
+func (c Hex) Distance(other Hex) int {
+	var (
+		q1, r1 = c.axial()
+		q2, r2 = other.axial()
+		dq     = q2 - q1
+		dr     = r2 - r1
+	)
+	return (iabs(dq) + iabs(dr) + iabs(dq+dr)) / 2
+}
+
+func iabs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
 //  Return a slice of the coordinates for adjacent hexagons
 //  (not necessarily in the grid).
-//  If E (or W) is supplied then the NE and SE (or NE and SW) coordinates
+//  If E (or W) is supplied then the NE and SE (or NW and SW) coordinates
 //  are returned in that order.
 //  If NilDirection is suppied, then coordinates for all adjacent hexagons
 //  are returned in the order N, NE, SE, S, SW, NW.
 func (coords Hex) Adjacents(dir hex.Direction) []Hex {
+	switch dir {
+	case hex.N, hex.NE, hex.NW, hex.S, hex.SE, hex.SW:
+		return []Hex{coords.Adjacent(dir)}
+	case hex.E:
+		return []Hex{coords.Adjacent(hex.NE), coords.Adjacent(hex.SE)}
+	case hex.W:
+		return []Hex{coords.Adjacent(hex.NW), coords.Adjacent(hex.SW)}
+	}
+	var adjAll = make([]Hex, 6)
+	for i, d := range adjacentsOrder {
+		adjAll[i] = coords.Adjacent(d)
+	}
+	return adjAll
+}
+
+var adjacentsOrder = []hex.Direction{hex.N, hex.NE, hex.SE, hex.S, hex.SW, hex.NW}
+
+//  The coordinates of the hexagon sharing the edge of coords in direction
+//  dir (not necessarily in the grid). Unlike Adjacents no memory is
+//  allocated. Returns coords if dir is not an edge direction.
+func (coords Hex) Adjacent(dir hex.Direction) Hex {
 	var (
-		u = coords.U
-		v = coords.V
+		u    = coords.U
+		v    = coords.V
+		high = ColumnIsHigh(u)
 	)
 	switch dir {
 	case hex.N:
-		return []Hex{Hex{u, v + 1}}
+		return Hex{u, v + 1}
 	case hex.S:
-		return []Hex{Hex{u, v - 1}}
-	case hex.E:
-		var adjE = make([]Hex, 2)
-		if ColumnIsHigh(u) {
-			adjE[0] = Hex{u - 1, v + 1}
-			adjE[1] = Hex{u - 1, v}
-		} else {
-			adjE[0] = Hex{u - 1, v}
-			adjE[1] = Hex{u - 1, v - 1}
-		}
-		return adjE
-	case hex.W:
-		var adjW = make([]Hex, 2)
-		if ColumnIsHigh(u) {
-			adjW[0] = Hex{u + 1, v + 1}
-			adjW[1] = Hex{u + 1, v}
-		} else {
-			adjW[0] = Hex{u + 1, v}
-			adjW[1] = Hex{u + 1, v - 1}
-		}
-		return adjW
+		return Hex{u, v - 1}
 	case hex.NE:
-		if ColumnIsHigh(u) {
-			return []Hex{Hex{u - 1, v + 1}}
+		if high {
+			return Hex{u + 1, v + 1}
 		}
-		return []Hex{Hex{u - 1, v}}
+		return Hex{u + 1, v}
 	case hex.NW:
-		if ColumnIsHigh(u) {
-			return []Hex{Hex{u + 1, v + 1}}
+		if high {
+			return Hex{u - 1, v + 1}
 		}
-		return []Hex{Hex{u + 1, v}}
+		return Hex{u - 1, v}
 	case hex.SE:
-		if ColumnIsHigh(u) {
-			return []Hex{Hex{u - 1, v}}
+		if high {
+			return Hex{u + 1, v}
 		}
-		return []Hex{Hex{u - 1, v - 1}}
+		return Hex{u + 1, v - 1}
 	case hex.SW:
-		if ColumnIsHigh(u) {
-			return []Hex{Hex{u + 1, v}}
-		}
-		return []Hex{Hex{u + 1, v - 1}}
-	default:
-		var adjAll = make([]Hex, 6)
-		if ColumnIsHigh(u) {
-			adjAll[0] = Hex{u, v + 1}     // North
-			adjAll[1] = Hex{u - 1, v + 1} // NorthEast
-			adjAll[2] = Hex{u - 1, v}     // SouthEast
-			adjAll[3] = Hex{u, v - 1}     // South
-			adjAll[4] = Hex{u + 1, v}     // SouthWest
-			adjAll[5] = Hex{u + 1, v + 1} // NorthWest
-		} else {
-			adjAll[0] = Hex{u, v + 1}
-			adjAll[1] = Hex{u - 1, v}
-			adjAll[2] = Hex{u - 1, v - 1}
-			adjAll[3] = Hex{u, v - 1}
-			adjAll[4] = Hex{u + 1, v - 1}
-			adjAll[5] = Hex{u + 1, v}
+		if high {
+			return Hex{u - 1, v}
 		}
-		return adjAll
+		return Hex{u - 1, v - 1}
 	}
-	return nil
+	return coords
 }
 
 func (vert Vertex) Incidents() []Hex {
@@ -541,7 +557,7 @@ var (
 		{{1, 0, 4}, {1, 1, 0}},
 		{{1, 1, 5}, {0, 1, 1}},
 		{{0, 1, 0}, {-1, 1, 2}},
-		{{-1, 1, 2}, {-1, 0, 3}}}
+		{{-1, 1, 1}, {-1, 0, 3}}}
 	hexLowVertexIncidenceOffset = [][][]int{
 		{{-1, -1, 2}, {0, -1, 4}},
 		{{0, -1, 3}, {1, -1, 5}},
@@ -6,6 +6,8 @@ Created: Sat Jul  2 02:01:22 PDT 2011
 package hexcoords
 
 import (
+    "github.com/bmatsuo/hexgrid/hex"
+
     "testing"
 )
 
@@ -26,3 +28,138 @@ func TestHighColumn(T *testing.T) {
         T.Error("-6th column is high. -6th column should be low.")
     }
 }
+
+func TestAdjacentsInverse(T *testing.T) {
+    for u := -3; u <= 3; u++ {
+        for v := -2; v <= 2; v++ {
+            var c = Hex{u, v}
+            for _, dir := range hex.EdgeDirections() {
+                var adj = c.Adjacents(dir)[0]
+                if back := adj.Adjacents(dir.Inverse())[0]; !back.Equals(c) {
+                    T.Errorf("%v -> %v -> %v is not %v", c, adj, back, c)
+                }
+                if c.Adjacency(adj) != dir {
+                    T.Errorf("adjacency of %v from %v is not %v", adj, c, dir)
+                }
+            }
+        }
+    }
+}
+
+func TestAdjacentsEastWest(T *testing.T) {
+    var tests = []struct {
+        c      Hex
+        dir    hex.Direction
+        expect []Hex
+    }{
+        {Hex{1, 0}, hex.E, []Hex{{2, 1}, {2, 0}}},
+        {Hex{1, 0}, hex.W, []Hex{{0, 1}, {0, 0}}},
+        {Hex{2, 0}, hex.E, []Hex{{3, 0}, {3, -1}}},
+        {Hex{2, 0}, hex.W, []Hex{{1, 0}, {1, -1}}},
+        {Hex{1, 0}, hex.NE, []Hex{{2, 1}}},
+        {Hex{2, 0}, hex.SW, []Hex{{1, -1}}},
+    }
+    for _, test := range tests {
+        var adj = test.c.Adjacents(test.dir)
+        if len(adj) != len(test.expect) {
+            T.Errorf("%v in direction %v: %v", test.c, test.dir, adj)
+            continue
+        }
+        for i := range adj {
+            if !adj[i].Equals(test.expect[i]) {
+                T.Errorf("%v in direction %v: %v, expected %v", test.c, test.dir, adj, test.expect)
+                break
+            }
+        }
+    }
+}
+
+func TestVertexIncidents(T *testing.T) {
+    for u := -2; u <= 2; u++ {
+        for k := 0; k < 6; k++ {
+            var (
+                vert  = Vertex{u, 0, k}
+                tiles = vert.Incidents()
+            )
+            if len(tiles) != 3 {
+                T.Errorf("vertex %v has incident tiles %v", vert, tiles)
+                continue
+            }
+            for i := range tiles {
+                if !tiles[(i+1)%3].IsAdjacent(tiles[i]) {
+                    T.Errorf("vertex %v: incident tiles %v are not mutually adjacent", vert, tiles)
+                    break
+                }
+            }
+            for _, ident := range vert.IdenticalVertices() {
+                if !ident.IsIdentical(vert) {
+                    T.Errorf("%v is identical to %v but not vice versa", vert, ident)
+                }
+            }
+        }
+    }
+}
+
+func TestIdenticalVerticesSymmetric(T *testing.T) {
+    for u := -3; u <= 3; u++ {
+        for k := 0; k < 6; k++ {
+            var vert = Vertex{u, 1, k}
+            for _, ident := range vert.IdenticalVertices() {
+                if !ident.IsIdentical(vert) {
+                    T.Errorf("%v is identical to %v but not vice versa", vert, ident)
+                }
+                if !ident.Canonical().Equals(vert.Canonical()) {
+                    T.Errorf("%v and %v have different canonical forms", vert, ident)
+                }
+            }
+        }
+    }
+}
+
+func TestEdgeCanonical(T *testing.T) {
+    for u := -3; u <= 3; u++ {
+        var c = Hex{u, 0}
+        for _, dir := range hex.EdgeDirections() {
+            var (
+                e     = c.Edges(dir)[0]
+                adj   = c.Adjacents(dir)[0]
+                other = adj.Edges(dir.Inverse())[0]
+            )
+            if !e.IsIdentical(other) {
+                T.Errorf("%v and %v are the same edge", e, other)
+            }
+            if canon := e.Canonical(); canon.K != 0 && canon.K != 1 && canon.K != 5 {
+                T.Errorf("canonical edge %v is not south facing", canon)
+            }
+            var shared = e.Incidents()
+            if len(shared) != 2 {
+                T.Errorf("edge %v has incident tiles %v", e, shared)
+            }
+        }
+    }
+}
+
+func TestDistance(T *testing.T) {
+    for u := -4; u <= 4; u++ {
+        var c = Hex{u, 1}
+        if d := c.Distance(c); d != 0 {
+            T.Errorf("distance from %v to itself is %d", c, d)
+        }
+        for _, adj := range c.Adjacents(hex.NilDirection) {
+            if d := c.Distance(adj); d != 1 {
+                T.Errorf("distance from %v to adjacent %v is %d", c, adj, d)
+            }
+            for _, adj2 := range adj.Adjacents(hex.NilDirection) {
+                if d := c.Distance(adj2); d > 2 || d != adj2.Distance(c) {
+                    T.Errorf("distance from %v to %v is %d", c, adj2, d)
+                }
+            }
+        }
+    }
+    if d := (Hex{0, 0}).Distance(Hex{0, -3}); d != 3 {
+        T.Errorf("vertical distance %d", d)
+    }
+    if d := (Hex{-2, 0}).Distance(Hex{2, 0}); d != 4 {
+        T.Errorf("horizontal distance %d", d)
+    }
+}
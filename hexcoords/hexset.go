@@ -0,0 +1,299 @@
+/*
+File: hexset.go
+Created: Mon Oct 19 01:21:40 UTC 2026
+*/
+
+package hexcoords
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+
+	"math/bits"
+	"sort"
+)
+
+//  A HexSet is a set of hex coordinates. A set created with NewBoundedHexSet
+//  stores coordinates within its bounds in a bitset, one bit per tile, and
+//  any others in a map. Sets created with NewHexSet use only the map.
+//
+//  Methods producing a new set give it the bounds of the receiver. Sets are
+//  iterated in column-major order: by increasing U, then increasing V.
+//
+//  The zero value is an empty, unbounded set ready to use.
+type HexSet struct {
+	min, max Hex
+	rows     int
+	bits     []uint64
+	extra    map[Hex]bool
+	n        int
+}
+
+//  An unbounded set containing cs.
+func NewHexSet(cs ...Hex) *HexSet {
+	var s = new(HexSet)
+	for _, c := range cs {
+		s.Add(c)
+	}
+	return s
+}
+
+//  An empty set storing coordinates between min and max (inclusive)
+//  compactly, as for the tiles of a grid.
+func NewBoundedHexSet(min, max Hex) *HexSet {
+	var s = &HexSet{min: min, max: max}
+	if min.U <= max.U && min.V <= max.V {
+		s.rows = max.V - min.V + 1
+		var size = (max.U - min.U + 1) * s.rows
+		s.bits = make([]uint64, (size+63)/64)
+	}
+	return s
+}
+
+//  An empty set with the same bounds as s.
+func (s *HexSet) empty() *HexSet {
+	if s.bits == nil {
+		return new(HexSet)
+	}
+	return NewBoundedHexSet(s.min, s.max)
+}
+
+//  The bit index of c, or -1 if c is outside the bounds of s.
+func (s *HexSet) bit(c Hex) int {
+	if s.bits == nil || c.U < s.min.U || c.U > s.max.U || c.V < s.min.V || c.V > s.max.V {
+		return -1
+	}
+	return (c.U-s.min.U)*s.rows + (c.V - s.min.V)
+}
+
+//  The number of coordinates in s.
+func (s *HexSet) Len() int {
+	return s.n
+}
+
+//  Returns true if c is in s.
+func (s *HexSet) Contains(c Hex) bool {
+	if i := s.bit(c); i >= 0 {
+		return s.bits[i/64]&(1<<uint(i%64)) != 0
+	}
+	return s.extra[c]
+}
+
+//  Add c to s. Returns false if c was already in s.
+func (s *HexSet) Add(c Hex) bool {
+	if s.Contains(c) {
+		return false
+	}
+	if i := s.bit(c); i >= 0 {
+		s.bits[i/64] |= 1 << uint(i%64)
+	} else {
+		if s.extra == nil {
+			s.extra = make(map[Hex]bool)
+		}
+		s.extra[c] = true
+	}
+	s.n++
+	return true
+}
+
+//  Remove c from s. Returns false if c was not in s.
+func (s *HexSet) Remove(c Hex) bool {
+	if !s.Contains(c) {
+		return false
+	}
+	if i := s.bit(c); i >= 0 {
+		s.bits[i/64] &^= 1 << uint(i%64)
+	} else {
+		delete(s.extra, c)
+	}
+	s.n--
+	return true
+}
+
+//  A copy of s.
+func (s *HexSet) Copy() *HexSet {
+	var cp = &HexSet{min: s.min, max: s.max, rows: s.rows, n: s.n}
+	if s.bits != nil {
+		cp.bits = append([]uint64(nil), s.bits...)
+	}
+	for c := range s.extra {
+		if cp.extra == nil {
+			cp.extra = make(map[Hex]bool, len(s.extra))
+		}
+		cp.extra[c] = true
+	}
+	return cp
+}
+
+//  The coordinates in s in column-major order.
+func (s *HexSet) Slice() []Hex {
+	var cs = make([]Hex, 0, s.n)
+	for i, word := range s.bits {
+		for ; word != 0; word &= word - 1 {
+			var b = i*64 + bits.TrailingZeros64(word)
+			cs = append(cs, Hex{s.min.U + b/s.rows, s.min.V + b%s.rows})
+		}
+	}
+	if len(s.extra) > 0 {
+		for c := range s.extra {
+			cs = append(cs, c)
+		}
+		sort.Slice(cs, func(i, j int) bool { return hexLess(cs[i], cs[j]) })
+	}
+	return cs
+}
+
+func hexLess(a, b Hex) bool {
+	return a.U < b.U || a.U == b.U && a.V < b.V
+}
+
+//  Call fn with each coordinate of s in column-major order. Modifying s
+//  during iteration does not affect the coordinates visited.
+func (s *HexSet) Each(fn func(Hex)) {
+	for _, c := range s.Slice() {
+		fn(c)
+	}
+}
+
+//  Returns true if s and t contain the same coordinates.
+func (s *HexSet) Equal(t *HexSet) bool {
+	if s.n != t.n {
+		return false
+	}
+	var equal = true
+	s.Each(func(c Hex) {
+		equal = equal && t.Contains(c)
+	})
+	return equal
+}
+
+//  Returns true if s and t store the same bounds in bitsets, so their
+//  words can be combined directly.
+func (s *HexSet) sameBits(t *HexSet) bool {
+	return s.bits != nil && t.bits != nil && s.min == t.min && s.max == t.max
+}
+
+//  Recompute the number of coordinates in s from its storage.
+func (s *HexSet) recount() {
+	s.n = len(s.extra)
+	for _, word := range s.bits {
+		s.n += bits.OnesCount64(word)
+	}
+}
+
+//  The coordinates in s, t or both.
+func (s *HexSet) Union(t *HexSet) *HexSet {
+	var u = s.Copy()
+	if s.sameBits(t) {
+		for i, word := range t.bits {
+			u.bits[i] |= word
+		}
+		u.recount()
+		for c := range t.extra {
+			u.Add(c)
+		}
+		return u
+	}
+	t.Each(func(c Hex) { u.Add(c) })
+	return u
+}
+
+//  The coordinates in both s and t.
+func (s *HexSet) Intersection(t *HexSet) *HexSet {
+	var u = s.empty()
+	if s.sameBits(t) {
+		for i, word := range s.bits {
+			u.bits[i] = word & t.bits[i]
+		}
+		u.recount()
+		for c := range s.extra {
+			if t.extra[c] {
+				u.Add(c)
+			}
+		}
+		return u
+	}
+	s.Each(func(c Hex) {
+		if t.Contains(c) {
+			u.Add(c)
+		}
+	})
+	return u
+}
+
+//  The coordinates in s but not in t.
+func (s *HexSet) Difference(t *HexSet) *HexSet {
+	var u = s.empty()
+	if s.sameBits(t) {
+		for i, word := range s.bits {
+			u.bits[i] = word &^ t.bits[i]
+		}
+		u.recount()
+		for c := range s.extra {
+			if !t.extra[c] {
+				u.Add(c)
+			}
+		}
+		return u
+	}
+	s.Each(func(c Hex) {
+		if !t.Contains(c) {
+			u.Add(c)
+		}
+	})
+	return u
+}
+
+//  The coordinates in s along with all coordinates adjacent to them.
+func (s *HexSet) Dilate() *HexSet {
+	var (
+		u    = s.Copy()
+		dirs = hex.EdgeDirections()
+	)
+	s.Each(func(c Hex) {
+		for _, dir := range dirs {
+			u.Add(c.Adjacent(dir))
+		}
+	})
+	return u
+}
+
+//  The coordinates in s all of whose adjacent coordinates are also in s.
+func (s *HexSet) Erode() *HexSet {
+	var (
+		u    = s.empty()
+		dirs = hex.EdgeDirections()
+	)
+	s.Each(func(c Hex) {
+		for _, dir := range dirs {
+			if !s.Contains(c.Adjacent(dir)) {
+				return
+			}
+		}
+		u.Add(c)
+	})
+	return u
+}
+
+//  The smallest range of coordinates, from min to max inclusive, containing
+//  every coordinate of s. The third return value is false if s is empty.
+func (s *HexSet) Range() (min, max Hex, ok bool) {
+	s.Each(func(c Hex) {
+		if !ok {
+			min, max, ok = c, c, true
+			return
+		}
+		if c.U < min.U {
+			min.U = c.U
+		}
+		if c.V < min.V {
+			min.V = c.V
+		}
+		if c.U > max.U {
+			max.U = c.U
+		}
+		if c.V > max.V {
+			max.V = c.V
+		}
+	})
+	return min, max, ok
+}
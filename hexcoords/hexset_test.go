@@ -0,0 +1,112 @@
+/*
+File: hexset_test.go
+Created: Mon Oct 19 01:21:40 UTC 2026
+*/
+
+package hexcoords
+
+import (
+	"testing"
+)
+
+func hexSets() []*HexSet {
+	return []*HexSet{new(HexSet), NewBoundedHexSet(Hex{-2, -2}, Hex{2, 2})}
+}
+
+func TestHexSetAddRemove(T *testing.T) {
+	for _, s := range hexSets() {
+		for _, c := range []Hex{{1, 1}, {5, 5}, {-2, 0}, {1, 1}} {
+			s.Add(c)
+		}
+		if s.Len() != 3 {
+			T.Errorf("length %d", s.Len())
+		}
+		if !s.Contains(Hex{5, 5}) || s.Contains(Hex{0, 0}) {
+			T.Errorf("membership wrong")
+		}
+		var cs = s.Slice()
+		if cs[0] != (Hex{-2, 0}) || cs[1] != (Hex{1, 1}) || cs[2] != (Hex{5, 5}) {
+			T.Errorf("order %v", cs)
+		}
+		if !s.Remove(Hex{1, 1}) || s.Remove(Hex{1, 1}) || s.Len() != 2 {
+			T.Errorf("remove failed")
+		}
+	}
+}
+
+func TestHexSetAlgebra(T *testing.T) {
+	for _, s := range hexSets() {
+		var t = s.Copy()
+		s.Add(Hex{0, 0})
+		s.Add(Hex{0, 1})
+		t.Add(Hex{0, 1})
+		t.Add(Hex{3, 3})
+		if u := s.Union(t); !u.Equal(NewHexSet(Hex{0, 0}, Hex{0, 1}, Hex{3, 3})) {
+			T.Errorf("union %v", u.Slice())
+		}
+		if u := s.Intersection(t); !u.Equal(NewHexSet(Hex{0, 1})) {
+			T.Errorf("intersection %v", u.Slice())
+		}
+		if u := s.Difference(t); !u.Equal(NewHexSet(Hex{0, 0})) {
+			T.Errorf("difference %v", u.Slice())
+		}
+		var min, max, ok = t.Range()
+		if !ok || min != (Hex{0, 1}) || max != (Hex{3, 3}) {
+			T.Errorf("range %v %v %v", min, max, ok)
+		}
+		if _, _, ok := new(HexSet).Range(); ok {
+			T.Errorf("range of empty set")
+		}
+	}
+}
+
+//  Set operations on bitsets with equal bounds agree with those on sets
+//  with differing bounds.
+func TestHexSetAlgebraBitsets(T *testing.T) {
+	var (
+		min, max = Hex{-4, -3}, Hex{5, 6}
+		s, t     = NewBoundedHexSet(min, max), NewBoundedHexSet(min, max)
+		other    = NewBoundedHexSet(Hex{-1, -1}, Hex{1, 1})
+	)
+	for u := -6; u <= 7; u++ {
+		for v := -5; v <= 8; v++ {
+			var c = Hex{u, v}
+			if (u*7+v*3)%4 != 0 {
+				s.Add(c)
+			}
+			if (u+v*5)%3 == 0 {
+				t.Add(c)
+				other.Add(c)
+			}
+		}
+	}
+	var checks = []struct {
+		name      string
+		fast, ref *HexSet
+	}{
+		{"union", s.Union(t), s.Union(other)},
+		{"intersection", s.Intersection(t), s.Intersection(other)},
+		{"difference", s.Difference(t), s.Difference(other)},
+	}
+	for _, check := range checks {
+		if !check.fast.Equal(check.ref) || check.fast.Len() != len(check.ref.Slice()) {
+			T.Errorf("%s: %v, expected %v", check.name, check.fast.Slice(), check.ref.Slice())
+		}
+	}
+}
+
+func TestHexSetDilateErode(T *testing.T) {
+	for _, s := range hexSets() {
+		s.Add(Hex{0, 0})
+		var d = s.Dilate()
+		if d.Len() != 7 {
+			T.Errorf("dilated size %d", d.Len())
+		}
+		if e := d.Erode(); !e.Equal(s) {
+			T.Errorf("eroded %v", e.Slice())
+		}
+		if d.Dilate().Len() != 19 {
+			T.Errorf("twice dilated size %d", d.Dilate().Len())
+		}
+	}
+}
@@ -0,0 +1,437 @@
+/*
+File: hierarchy.go
+Created: Mon Oct 19 07:18:26 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+//  A HierarchicalPathfinder finds paths over large grids with the HPA*
+//  algorithm. The grid is divided into square clusters of tiles. Where two
+//  clusters touch, each run of tiles that can be crossed between them gets
+//  an entrance, and the costs of the paths between the entrances of every
+//  cluster are computed in advance. A search runs A* over the entrances and
+//  then expands each step into tiles with a search confined to one cluster,
+//  so its cost depends on the number of clusters crossed rather than the
+//  number of tiles.
+//
+//  Paths found are close to, but not always, the cheapest. Path costs
+//  assume every move costs at least 1, as for Grid.ShortestPath.
+//
+//  The pathfinder observes its grid. A change to a tile, edge or vertex
+//  marks the clusters around it stale and only those clusters are rebuilt,
+//  before the next search. Call Invalidate when step costs change for
+//  reasons the grid does not see.
+//
+//  Changes may be made and Invalidate called from any goroutine, including
+//  writers of a ConcurrentGrid, but only one search may run at a time. A
+//  StepCost reading a grid shared through a ConcurrentGrid should read it
+//  through the ConcurrentGrid.
+type HierarchicalPathfinder struct {
+	grid      *Grid
+	cost      StepCost
+	size      int
+	rows      int // Clusters in each column of clusters.
+	sub       *Subscription
+	mu        sync.Mutex // Guards stale.
+	stale     map[int]bool
+	adjacent  [][]int // The clusters touching each cluster.
+	crossings map[clusterPair][]crossing
+	entrances [][]int                 // Entrance slots of each cluster.
+	inner     map[int]map[int]float64 // Path costs between entrances of a cluster.
+	outer     map[int]map[int]float64 // Step costs between entrances of touching clusters.
+}
+
+//  Clusters a < b.
+type clusterPair struct{ a, b int }
+
+//  Adjacent tiles in clusters a and b of a clusterPair.
+type crossing struct{ a, b int }
+
+//  The number of crossings in a run above which the run gets an entrance
+//  at each end instead of one in the middle.
+const maxEntranceRun = 6
+
+//  A hierarchical pathfinder for h with clusters of size by size tiles.
+//  Sizes below 1 are treated as 1.
+func (h *Grid) NewHierarchicalPathfinder(size int, cost StepCost) *HierarchicalPathfinder {
+	if size < 1 {
+		size = 1
+	}
+	var (
+		rows  = (h.m + size - 1) / size
+		count = (h.n + size - 1) / size * rows
+		p     = &HierarchicalPathfinder{
+			grid:      h,
+			cost:      cost,
+			size:      size,
+			rows:      rows,
+			stale:     make(map[int]bool),
+			adjacent:  make([][]int, count),
+			crossings: make(map[clusterPair][]crossing),
+			entrances: make([][]int, count),
+			inner:     make(map[int]map[int]float64),
+			outer:     make(map[int]map[int]float64),
+		}
+	)
+	for cl := 0; cl < count; cl++ {
+		var touching = make(map[int]bool)
+		p.eachTile(cl, func(slot int) {
+			h.eachAdjacent(h.slotHex(slot), func(adj hexcoords.Hex) {
+				if other := p.cluster(h.tileSlot(adj)); other != cl {
+					touching[other] = true
+				}
+			})
+		})
+		for other := range touching {
+			p.adjacent[cl] = append(p.adjacent[cl], other)
+		}
+		sort.Ints(p.adjacent[cl])
+		p.stale[cl] = true
+	}
+	p.sub = h.Observe(func(ch Change) {
+		for _, c := range ch.Incidents() {
+			p.Invalidate(c)
+		}
+	})
+	p.refresh()
+	return p
+}
+
+//  Stop observing the grid. Later changes are only seen through
+//  Invalidate.
+func (p *HierarchicalPathfinder) Close() {
+	p.sub.Cancel()
+}
+
+//  Mark the cluster containing the tile at c stale, because the cost of
+//  moving into or out of the tile changed.
+func (p *HierarchicalPathfinder) Invalidate(c hexcoords.Hex) {
+	if c, ok := p.grid.Wrap(c); ok {
+		p.mu.Lock()
+		p.stale[p.cluster(p.grid.tileSlot(c))] = true
+		p.mu.Unlock()
+	}
+}
+
+//  The cluster containing the tile in slot.
+func (p *HierarchicalPathfinder) cluster(slot int) int {
+	var i, j = slot / p.grid.m, slot % p.grid.m
+	return i/p.size*p.rows + j/p.size
+}
+
+//  Call fn with the slot of each tile of cluster cl.
+func (p *HierarchicalPathfinder) eachTile(cl int, fn func(slot int)) {
+	var (
+		h      = p.grid
+		ci, cj = cl / p.rows * p.size, cl % p.rows * p.size
+	)
+	for i := ci; i < imin(ci+p.size, h.n); i++ {
+		for j := cj; j < imin(cj+p.size, h.m); j++ {
+			fn(i*h.m + j)
+		}
+	}
+}
+
+//  Rebuild the entrances of the stale clusters and their neighbors.
+func (p *HierarchicalPathfinder) refresh() {
+	p.mu.Lock()
+	var stale = p.stale
+	p.stale = make(map[int]bool)
+	p.mu.Unlock()
+	if len(stale) == 0 {
+		return
+	}
+	var (
+		affected = make(map[int]bool)
+		done     = make(map[clusterPair]bool)
+	)
+	for cl := range stale {
+		affected[cl] = true
+		for _, other := range p.adjacent[cl] {
+			affected[other] = true
+			var pair = clusterPair{imin(cl, other), imax(cl, other)}
+			if !done[pair] {
+				done[pair] = true
+				p.findCrossings(pair)
+			}
+		}
+	}
+	for cl := range affected {
+		p.connect(cl)
+	}
+}
+
+//  Choose the crossings between the clusters of pair. Adjacent tile pairs
+//  that can be crossed the same way form runs along the border, and each
+//  run is crossed at its middle or, if it is long, at both ends.
+func (p *HierarchicalPathfinder) findCrossings(pair clusterPair) {
+	var h = p.grid
+	for _, x := range p.crossings[pair] {
+		delete(p.outer[x.a], x.b)
+		delete(p.outer[x.b], x.a)
+	}
+	var (
+		found []crossing
+		ways  []int // Bit 0 if a to b is passable, bit 1 if b to a is.
+	)
+	p.eachTile(pair.a, func(slot int) {
+		var c = h.slotHex(slot)
+		h.eachAdjacent(c, func(adj hexcoords.Hex) {
+			var adjSlot = h.tileSlot(adj)
+			if p.cluster(adjSlot) != pair.b {
+				return
+			}
+			var way int
+			if passable(p.cost.between(c, adj)) {
+				way |= 1
+			}
+			if passable(p.cost.between(adj, c)) {
+				way |= 2
+			}
+			if way != 0 {
+				found = append(found, crossing{slot, adjSlot})
+				ways = append(ways, way)
+			}
+		})
+	})
+
+	var (
+		touching = func(a, b int) bool {
+			return a == b || h.Distance(h.slotHex(a), h.slotHex(b)) == 1
+		}
+		run    = make([]int, len(found))
+		chosen []crossing
+	)
+	for i := range run {
+		run[i] = -1
+	}
+	for i := range found {
+		if run[i] >= 0 {
+			continue
+		}
+		var members, queue = []int{i}, []int{i}
+		run[i] = i
+		for len(queue) > 0 {
+			var k = queue[0]
+			queue = queue[1:]
+			for l := range found {
+				if run[l] < 0 && ways[l] == ways[k] &&
+					touching(found[l].a, found[k].a) && touching(found[l].b, found[k].b) {
+					run[l] = i
+					members = append(members, l)
+					queue = append(queue, l)
+				}
+			}
+		}
+		sort.Ints(members)
+		if len(members) > maxEntranceRun {
+			chosen = append(chosen, found[members[0]], found[members[len(members)-1]])
+		} else {
+			chosen = append(chosen, found[members[len(members)/2]])
+		}
+	}
+
+	for _, x := range chosen {
+		var a, b = h.slotHex(x.a), h.slotHex(x.b)
+		if step := p.cost.between(a, b); passable(step) {
+			p.link(p.outer, x.a, x.b, step)
+		}
+		if step := p.cost.between(b, a); passable(step) {
+			p.link(p.outer, x.b, x.a, step)
+		}
+	}
+	p.crossings[pair] = chosen
+}
+
+func (p *HierarchicalPathfinder) link(links map[int]map[int]float64, from, to int, cost float64) {
+	if links[from] == nil {
+		links[from] = make(map[int]float64)
+	}
+	links[from][to] = cost
+}
+
+//  Collect the entrances of cluster cl from its crossings and compute the
+//  path costs between them.
+func (p *HierarchicalPathfinder) connect(cl int) {
+	for _, slot := range p.entrances[cl] {
+		delete(p.inner, slot)
+	}
+	var set = make(map[int]bool)
+	for _, other := range p.adjacent[cl] {
+		var pair = clusterPair{imin(cl, other), imax(cl, other)}
+		for _, x := range p.crossings[pair] {
+			if pair.a == cl {
+				set[x.a] = true
+			} else {
+				set[x.b] = true
+			}
+		}
+	}
+	var entrances = make([]int, 0, len(set))
+	for slot := range set {
+		entrances = append(entrances, slot)
+	}
+	sort.Ints(entrances)
+	for _, from := range entrances {
+		var dist, _ = p.search(cl, from, -1, false)
+		for _, to := range entrances {
+			if d, ok := dist[to]; ok && to != from {
+				p.link(p.inner, from, to, d)
+			}
+		}
+	}
+	p.entrances[cl] = entrances
+}
+
+//  Dijkstra's algorithm from the tile in slot from, confined to cluster cl.
+//  The search stops once the tile in slot goal is reached; a negative goal
+//  searches the whole cluster. When reverse is true the distances are those
+//  of paths leading to from instead of away from it. Returns the distance
+//  and previous slot of each tile reached.
+func (p *HierarchicalPathfinder) search(cl, from, goal int, reverse bool) (map[int]float64, map[int]int) {
+	var (
+		h      = p.grid
+		dist   = make(map[int]float64, p.size*p.size)
+		parent = make(map[int]int, p.size*p.size)
+		open   = new(slotQueue)
+	)
+	dist[from], parent[from] = 0, -1
+	heap.Push(open, slotItem{from, 0})
+	for open.Len() > 0 {
+		var item = heap.Pop(open).(slotItem)
+		if item.slot == goal {
+			break
+		}
+		if item.priority > dist[item.slot] {
+			continue
+		}
+		var c = h.slotHex(item.slot)
+		h.eachAdjacent(c, func(adj hexcoords.Hex) {
+			var adjSlot = h.tileSlot(adj)
+			if p.cluster(adjSlot) != cl {
+				return
+			}
+			var step float64
+			if reverse {
+				step = p.cost.between(adj, c)
+			} else {
+				step = p.cost.between(c, adj)
+			}
+			if !passable(step) {
+				return
+			}
+			var d = item.priority + step
+			if old, ok := dist[adjSlot]; ok && old <= d {
+				return
+			}
+			dist[adjSlot] = d
+			parent[adjSlot] = item.slot
+			heap.Push(open, slotItem{adjSlot, d})
+		})
+	}
+	return dist, parent
+}
+
+//  Find a path of adjacent tiles from the tile at from to the tile at to,
+//  rebuilding stale clusters first. The results are as for
+//  Grid.ShortestPath: the path includes both ends, the second return value
+//  is its total cost and the third is false if to cannot be reached.
+func (p *HierarchicalPathfinder) ShortestPath(from, to hexcoords.Hex) ([]hexcoords.Hex, float64, bool) {
+	var h = p.grid
+	from, okFrom := h.Wrap(from)
+	to, okTo := h.Wrap(to)
+	if !okFrom || !okTo {
+		return nil, 0, false
+	}
+	p.refresh()
+	var (
+		start       = h.tileSlot(from)
+		goal        = h.tileSlot(to)
+		startCl     = p.cluster(start)
+		goalCl      = p.cluster(goal)
+		exits, _    = p.search(startCl, start, -1, false)
+		arrivals, _ = p.search(goalCl, goal, -1, true)
+		dist        = map[int]float64{start: 0}
+		parent      = map[int]int{start: -1}
+		closed      = make(map[int]bool)
+		open        = new(slotQueue)
+	)
+	var relax = func(slot, next int, step float64) {
+		var d = dist[slot] + step
+		if old, ok := dist[next]; ok && old <= d {
+			return
+		}
+		dist[next] = d
+		parent[next] = slot
+		heap.Push(open, slotItem{next, d + float64(h.Distance(h.slotHex(next), to))})
+	}
+	heap.Push(open, slotItem{start, float64(h.Distance(from, to))})
+	for open.Len() > 0 {
+		var slot = heap.Pop(open).(slotItem).slot
+		if slot == goal {
+			break
+		}
+		if closed[slot] {
+			continue
+		}
+		closed[slot] = true
+		if slot == start {
+			for _, e := range p.entrances[startCl] {
+				if d, ok := exits[e]; ok {
+					relax(slot, e, d)
+				}
+			}
+		}
+		for next, step := range p.inner[slot] {
+			relax(slot, next, step)
+		}
+		for next, step := range p.outer[slot] {
+			relax(slot, next, step)
+		}
+		if p.cluster(slot) == goalCl {
+			if d, ok := arrivals[slot]; ok {
+				relax(slot, goal, d)
+			}
+		}
+	}
+	var total, ok = dist[goal]
+	if !ok {
+		return nil, 0, false
+	}
+
+	var waypoints []int
+	for slot := goal; slot >= 0; slot = parent[slot] {
+		waypoints = append(waypoints, slot)
+	}
+	var path = []hexcoords.Hex{from}
+	for k := len(waypoints) - 1; k > 0; k-- {
+		var a, b = waypoints[k], waypoints[k-1]
+		if cl := p.cluster(a); cl != p.cluster(b) {
+			path = append(path, h.slotHex(b))
+		} else {
+			var (
+				_, prev = p.search(cl, a, b, false)
+				n       = len(path)
+			)
+			if _, ok := prev[b]; !ok {
+				// Costs changed without Invalidate.
+				return nil, 0, false
+			}
+			for slot := b; slot != a; slot = prev[slot] {
+				path = append(path, h.slotHex(slot))
+			}
+			for i, j := n, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+		}
+	}
+	return path, total, true
+}
@@ -0,0 +1,178 @@
+/*
+File: hierarchy_test.go
+Created: Mon Oct 19 07:18:26 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//  Check that path is a walk of adjacent tiles from a to b costing total.
+func checkPath(T *testing.T, h *Grid, cost StepCost, path []hexcoords.Hex, a, b hexcoords.Hex, total float64) {
+	if len(path) == 0 || path[0] != a || path[len(path)-1] != b {
+		T.Errorf("path %v from %v to %v", path, a, b)
+		return
+	}
+	var sum float64
+	for k := 1; k < len(path); k++ {
+		if h.Distance(path[k-1], path[k]) != 1 {
+			T.Errorf("path %v from %v to %v: %v and %v not adjacent", path, a, b, path[k-1], path[k])
+			return
+		}
+		sum += cost.between(path[k-1], path[k])
+	}
+	if math.Abs(sum-total) > 1e-9 {
+		T.Errorf("path from %v to %v costs %v, reported %v", a, b, sum, total)
+	}
+}
+
+func TestHierarchicalPathUniform(T *testing.T) {
+	var (
+		h   = NewGrid(23, 17, 1, nil, nil, nil)
+		p   = h.NewHierarchicalPathfinder(5, nil)
+		rng = rand.New(rand.NewSource(3))
+	)
+	for k := 0; k < 200; k++ {
+		var a, b = randomHex(h, rng), randomHex(h, rng)
+		var path, total, ok = p.ShortestPath(a, b)
+		if !ok {
+			T.Fatalf("no path from %v to %v", a, b)
+		}
+		checkPath(T, h, nil, path, a, b, total)
+		if d := float64(h.Distance(a, b)); total < d || total > 1.5*d+2 {
+			T.Errorf("path from %v to %v costs %v, distance %v", a, b, total, d)
+		}
+	}
+	if _, _, ok := p.ShortestPath(hexcoords.Hex{0, 0}, hexcoords.Hex{20, 0}); ok {
+		T.Errorf("path to a tile outside the grid")
+	}
+}
+
+func TestHierarchicalPathTerrain(T *testing.T) {
+	var (
+		h    = NewWrappedGrid(30, 21, 1, WrapHorizontal, nil, nil, nil)
+		rng  = rand.New(rand.NewSource(8))
+		cost = testTerrainCost(h)
+	)
+	randomTerrain(h, rng)
+	var (
+		p        = h.NewHierarchicalPathfinder(6, cost)
+		excess   float64
+		cheapest float64
+	)
+	for k := 0; k < 300; k++ {
+		var a, b = randomHex(h, rng), randomHex(h, rng)
+		if h.TileValue(a) == nil || h.TileValue(b) == nil {
+			continue
+		}
+		var (
+			_, best, reachable = h.ShortestPath(a, b, cost)
+			path, total, ok    = p.ShortestPath(a, b)
+		)
+		if ok != reachable {
+			T.Errorf("path from %v to %v found %v, reachable %v", a, b, ok, reachable)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		checkPath(T, h, cost, path, a, b, total)
+		if total < best-1e-9 {
+			T.Errorf("path from %v to %v costs %v, cheaper than %v", a, b, total, best)
+		}
+		excess += total - best
+		cheapest += best
+	}
+	if cheapest == 0 {
+		T.Fatalf("no paths found")
+	}
+	if r := excess / cheapest; r > 0.2 {
+		T.Errorf("paths cost %.0f%% more than the cheapest", 100*r)
+	}
+}
+
+func TestHierarchicalPathUpdate(T *testing.T) {
+	var (
+		h    = NewWrappedGrid(26, 19, 1, WrapHorizontal, nil, nil, nil)
+		rng  = rand.New(rand.NewSource(21))
+		cost = testTerrainCost(h)
+	)
+	randomTerrain(h, rng)
+	var p = h.NewHierarchicalPathfinder(4, cost)
+	defer p.Close()
+	for round := 0; round < 5; round++ {
+		for k := 0; k < 15; k++ {
+			var c = randomHex(h, rng)
+			if rng.Intn(3) == 0 {
+				h.SetTileValue(c, nil)
+			} else {
+				h.SetTileValue(c, float64(1+rng.Intn(4)))
+			}
+		}
+		var fresh = h.NewHierarchicalPathfinder(4, cost)
+		for k := 0; k < 40; k++ {
+			var (
+				a, b         = randomHex(h, rng), randomHex(h, rng)
+				_, got, ok1  = p.ShortestPath(a, b)
+				_, want, ok2 = fresh.ShortestPath(a, b)
+			)
+			if ok1 != ok2 || got != want {
+				T.Errorf("round %d: path from %v to %v costs %v %v, rebuilt %v %v", round, a, b, got, ok1, want, ok2)
+			}
+		}
+		fresh.Close()
+	}
+
+	var (
+		blocked = make(map[hexcoords.Hex]bool)
+		walls   = StepCost(func(from, to hexcoords.Hex) float64 {
+			if blocked[to] {
+				return -1
+			}
+			return 1
+		})
+		q    = h.NewHierarchicalPathfinder(4, walls)
+		a, b = hexcoords.Hex{-4, 0}, hexcoords.Hex{4, 0}
+	)
+	if _, total, _ := q.ShortestPath(a, b); total != 8 {
+		T.Errorf("path costs %v without walls", total)
+	}
+	for v := h.RowMin(); v <= h.RowMax(); v++ {
+		blocked[hexcoords.Hex{0, v}] = true
+		q.Invalidate(hexcoords.Hex{0, v})
+	}
+	var path, total, ok = q.ShortestPath(a, b)
+	if !ok || total <= 8 {
+		T.Fatalf("path %v costs %v %v through a wall", path, total, ok)
+	}
+	checkPath(T, h, walls, path, a, b, total)
+}
+
+func BenchmarkHierarchicalPath(B *testing.B) {
+	var (
+		h   = NewCompactGrid(201, 201, 1, nil, nil, nil)
+		p   = h.NewHierarchicalPathfinder(10, nil)
+		rng = rand.New(rand.NewSource(1))
+	)
+	B.ResetTimer()
+	for i := 0; i < B.N; i++ {
+		p.ShortestPath(randomHex(h, rng), randomHex(h, rng))
+	}
+}
+
+func BenchmarkShortestPath(B *testing.B) {
+	var (
+		h   = NewCompactGrid(201, 201, 1, nil, nil, nil)
+		rng = rand.New(rand.NewSource(1))
+	)
+	B.ResetTimer()
+	for i := 0; i < B.N; i++ {
+		h.ShortestPath(randomHex(h, rng), randomHex(h, rng), nil)
+	}
+}
@@ -0,0 +1,275 @@
+/*
+File: history.go
+Created: Sun Oct 18 23:58:04 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"sync"
+	"sync/atomic"
+)
+
+//  The kind of object whose value a Change modifies.
+type ChangeKind int
+
+const (
+	TileChange ChangeKind = iota
+	VertexChange
+	EdgeChange
+)
+
+//  A Change records the modification of a single value in a Grid. Only the
+//  coordinate field matching Kind is meaningful. Vertex and edge
+//  coordinates are canonical.
+type Change struct {
+	Kind   ChangeKind
+	Hex    hexcoords.Hex
+	Vertex hexcoords.Vertex
+	Edge   hexcoords.Edge
+	Old    Value
+	New    Value
+}
+
+//  The change that reverts ch.
+func (ch Change) Inverse() Change {
+	ch.Old, ch.New = ch.New, ch.Old
+	return ch
+}
+
+//  A History records the value changes made to a Grid through its
+//  SetTileValue, SetVertexValue and SetEdgeValue methods, so they may be
+//  undone and redone. Assigning the Value field of objects returned by
+//  GetTile, GetVertex or GetEdge is not recorded.
+//
+//  Named checkpoints mark positions in the history that can be returned to
+//  with Rollback. Making a new change after undoing discards the undone
+//  changes, along with any checkpoints that refer to them.
+//
+//  A History may be used from multiple goroutines, and changes made through
+//  a ConcurrentGrid in different regions are recorded one at a time. Undo,
+//  Redo and Rollback modify values without the locks of a ConcurrentGrid;
+//  call them only while no other goroutine accesses the grid.
+type History struct {
+	grid        *Grid
+	done        []Change
+	undone      []Change // In the order they will be redone (last first).
+	checkpoints map[string]int
+}
+
+//  The state a grid shares between goroutines writing its values. Writers
+//  holding different locks of a ConcurrentGrid serialize on mu to record
+//  their changes and queue them for observers. Observers are called later
+//  without mu held (see Grid.deliver).
+type events struct {
+	mu        sync.Mutex
+	history   *History
+	recording int32 // Nonzero while history is non-nil. Accessed atomically.
+	// The observers and the changes waiting for them have their own lock,
+	// which is never held while an observer runs.
+	subsMu     sync.Mutex
+	subs       []*Subscription
+	observed   int32 // The length of subs. Accessed atomically.
+	queue      []notification
+	delivering bool // A goroutine is calling observers.
+}
+
+//  Begin recording changes to the values of h. If h already records its
+//  history the existing History is returned.
+func (h *Grid) StartHistory() *History {
+	var ev = h.events
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	if ev.history == nil {
+		ev.history = &History{grid: h, checkpoints: make(map[string]int)}
+		atomic.StoreInt32(&ev.recording, 1)
+	}
+	return ev.history
+}
+
+//  The history recorded for h, or nil if StartHistory has not been called.
+func (h *Grid) History() *History {
+	var ev = h.events
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	return ev.history
+}
+
+//  Stop recording changes to the values of h and discard its history.
+func (h *Grid) StopHistory() {
+	var ev = h.events
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	ev.history = nil
+	atomic.StoreInt32(&ev.recording, 0)
+}
+
+//  Store the new value of ch, record it in the grid's history and queue it
+//  for observers. The lock is only taken while history is recorded or the
+//  grid is observed, so concurrent writers do not otherwise contend. The
+//  caller delivers the change once it holds no locks (see Grid.deliver).
+func (h *Grid) applyChange(ch Change) {
+	h.storeValue(ch)
+	var ev = h.events
+	if atomic.LoadInt32(&ev.recording) == 0 && atomic.LoadInt32(&ev.observed) == 0 {
+		return
+	}
+	ev.mu.Lock()
+	if ev.history != nil {
+		ev.history.record(ch)
+	}
+	h.post(ch)
+	ev.mu.Unlock()
+}
+
+//  The lock guarding hist, shared with the writers of its grid.
+func (hist *History) lock() *sync.Mutex {
+	return &hist.grid.events.mu
+}
+
+func (hist *History) record(ch Change) {
+	hist.undone = hist.undone[:0]
+	for name, pos := range hist.checkpoints {
+		if pos > len(hist.done) {
+			delete(hist.checkpoints, name)
+		}
+	}
+	hist.done = append(hist.done, ch)
+}
+
+//  The number of changes that can be undone.
+func (hist *History) Len() int {
+	var mu = hist.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	return len(hist.done)
+}
+
+//  The changes that can be undone, oldest first.
+func (hist *History) Changes() []Change {
+	var mu = hist.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Change(nil), hist.done...)
+}
+
+//  Returns true if there is a change to undo.
+func (hist *History) CanUndo() bool {
+	var mu = hist.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	return len(hist.done) > 0
+}
+
+//  Returns true if there is an undone change to redo.
+func (hist *History) CanRedo() bool {
+	var mu = hist.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	return len(hist.undone) > 0
+}
+
+//  Run step with the history locked, then notify the observers of the
+//  changes it made once the lock is released.
+func (hist *History) apply(step func() []Change) []Change {
+	var mu = hist.lock()
+	mu.Lock()
+	var changes = step()
+	for _, ch := range changes {
+		hist.grid.post(ch)
+	}
+	mu.Unlock()
+	hist.grid.deliver()
+	return changes
+}
+
+//  Revert the most recent change. Returns false if there was nothing to
+//  undo.
+func (hist *History) Undo() bool {
+	return len(hist.apply(func() []Change { return hist.undo(nil) })) > 0
+}
+
+//  Append the change reverting the most recent change to changes, after
+//  storing it.
+func (hist *History) undo(changes []Change) []Change {
+	if len(hist.done) == 0 {
+		return changes
+	}
+	var ch = hist.done[len(hist.done)-1]
+	hist.done = hist.done[:len(hist.done)-1]
+	hist.undone = append(hist.undone, ch)
+	hist.grid.storeValue(ch.Inverse())
+	return append(changes, ch.Inverse())
+}
+
+//  Reapply the most recently undone change. Returns false if there was
+//  nothing to redo.
+func (hist *History) Redo() bool {
+	return len(hist.apply(func() []Change { return hist.redo(nil) })) > 0
+}
+
+//  Append the most recently undone change to changes, after storing it.
+func (hist *History) redo(changes []Change) []Change {
+	if len(hist.undone) == 0 {
+		return changes
+	}
+	var ch = hist.undone[len(hist.undone)-1]
+	hist.undone = hist.undone[:len(hist.undone)-1]
+	hist.done = append(hist.done, ch)
+	hist.grid.storeValue(ch)
+	return append(changes, ch)
+}
+
+//  Name the current position in the history. An existing checkpoint with
+//  the same name is moved.
+func (hist *History) Checkpoint(name string) {
+	var mu = hist.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	hist.checkpoints[name] = len(hist.done)
+}
+
+//  Returns true if a checkpoint with the given name exists.
+func (hist *History) HasCheckpoint(name string) bool {
+	var mu = hist.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	var _, ok = hist.checkpoints[name]
+	return ok
+}
+
+//  Undo or redo changes until the grid is in the state it was in when the
+//  named checkpoint was made. Returns false if there is no such checkpoint.
+func (hist *History) Rollback(name string) bool {
+	var ok bool
+	hist.apply(func() []Change {
+		var (
+			pos     int
+			changes []Change
+		)
+		if pos, ok = hist.checkpoints[name]; !ok {
+			return nil
+		}
+		for len(hist.done) > pos {
+			changes = hist.undo(changes)
+		}
+		for len(hist.done) < pos {
+			changes = hist.redo(changes)
+		}
+		return changes
+	})
+	return ok
+}
+
+//  Forget all recorded changes and checkpoints. The values of the grid are
+//  not modified.
+func (hist *History) Clear() {
+	var mu = hist.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	hist.done = nil
+	hist.undone = nil
+	hist.checkpoints = make(map[string]int)
+}
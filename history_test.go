@@ -0,0 +1,116 @@
+/*
+File: history_test.go
+Created: Sun Oct 18 23:58:04 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestHistoryUndoRedo(T *testing.T) {
+	for _, h := range []*Grid{NewGrid(5, 5, 1, 0, 0, 0), NewCompactGrid(5, 5, 1, 0, 0, 0)} {
+		var (
+			hist = h.StartHistory()
+			c    = hexcoords.Hex{1, 1}
+			vc   = hexcoords.Vertex{1, 1, 3}
+			ec   = c.Edges(hex.N)[0]
+		)
+		h.SetTileValue(c, 1)
+		h.SetVertexValue(vc, 2)
+		h.SetEdgeValue(ec, 3)
+		if hist.Len() != 3 {
+			T.Fatalf("compact=%v: %d changes recorded", h.IsCompact(), hist.Len())
+		}
+		if ch := hist.Changes()[1]; ch.Kind != VertexChange || ch.Vertex != vc.Canonical() {
+			T.Errorf("compact=%v: vertex change %#v", h.IsCompact(), ch)
+		}
+		for hist.Undo() {
+		}
+		if h.TileValue(c) != 0 || h.VertexValue(vc) != 0 || h.EdgeValue(ec) != 0 {
+			T.Errorf("compact=%v: values not restored by undo", h.IsCompact())
+		}
+		if !hist.Redo() || !hist.Redo() {
+			T.Fatalf("compact=%v: redo failed", h.IsCompact())
+		}
+		if h.TileValue(c) != 1 || h.VertexValue(vc) != 2 || h.EdgeValue(ec) != 0 {
+			T.Errorf("compact=%v: values not restored by redo", h.IsCompact())
+		}
+		h.SetTileValue(c, 4)
+		if hist.CanRedo() {
+			T.Errorf("compact=%v: new change did not discard redo", h.IsCompact())
+		}
+	}
+}
+
+func TestHistoryRollback(T *testing.T) {
+	var (
+		h    = NewGrid(5, 5, 1, 0, 0, 0)
+		hist = h.StartHistory()
+		c    = hexcoords.Hex{0, 0}
+	)
+	h.SetTileValue(c, 1)
+	hist.Checkpoint("one")
+	h.SetTileValue(c, 2)
+	h.SetTileValue(c, 3)
+	hist.Checkpoint("three")
+	if !hist.Rollback("one") || h.TileValue(c) != 1 {
+		T.Errorf("rollback to one: value %v", h.TileValue(c))
+	}
+	if !hist.Rollback("three") || h.TileValue(c) != 3 {
+		T.Errorf("rollback to three: value %v", h.TileValue(c))
+	}
+	hist.Rollback("one")
+	h.SetTileValue(c, 5)
+	if hist.HasCheckpoint("three") {
+		T.Errorf("checkpoint of discarded changes kept")
+	}
+	if hist.Rollback("three") {
+		T.Errorf("rollback to discarded checkpoint")
+	}
+	if !hist.HasCheckpoint("one") {
+		T.Errorf("checkpoint one discarded")
+	}
+	if h.Clone().History() != nil {
+		T.Errorf("clone shares history")
+	}
+}
+
+//  Observers may read the grid and its history while changes are made,
+//  undone and rolled back.
+func TestHistoryObserved(T *testing.T) {
+	var (
+		h    = NewGrid(5, 5, 1, 0, 0, 0)
+		hist = h.StartHistory()
+		c    = hexcoords.Hex{0, 0}
+		seen []Value
+		lens []int
+	)
+	h.Observe(func(ch Change) {
+		seen = append(seen, h.TileValue(c))
+		lens = append(lens, h.History().Len())
+	})
+	h.SetTileValue(c, 1)
+	hist.Checkpoint("one")
+	h.SetTileValue(c, 2)
+	hist.Undo()
+	hist.Redo()
+	hist.Rollback("one")
+	var (
+		expectSeen = []Value{1, 2, 1, 2, 1}
+		expectLens = []int{1, 2, 1, 2, 1}
+	)
+	if len(seen) != len(expectSeen) {
+		T.Fatalf("observed values %v, expected %v", seen, expectSeen)
+	}
+	for i := range seen {
+		if seen[i] != expectSeen[i] || lens[i] != expectLens[i] {
+			T.Errorf("observation %d: value %v and %d changes, expected %v and %d",
+				i, seen[i], lens[i], expectSeen[i], expectLens[i])
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+File: influence.go
+Created: Mon Oct 19 06:02:15 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"container/heap"
+	"math"
+)
+
+//  An InfluenceMap sums the influence of sources, such as units or cities,
+//  over the tiles of a grid. A source of strength s influences a tile at
+//  path cost d from it by s*decay^d, out to a maximum distance. Path costs
+//  are given by a StepCost, measured from the source outward, and paths
+//  cross wrapping borders. Negative strengths can represent opposing sides
+//  on a single map.
+//
+//  The contribution of each source is kept, so adding, removing, moving or
+//  changing the strength of a source only updates the tiles it influences.
+//  The map does not observe its grid; call Recompute after changes
+//  affecting step costs.
+type InfluenceMap struct {
+	grid        *Grid
+	cost        StepCost
+	decay       float64
+	maxDistance float64
+	sources     map[int]*influenceSource
+	influence   []float64 // By tile slot.
+}
+
+type influenceSource struct {
+	strength float64
+	slots    []int
+	weights  []float64 // Influence on slots, before strength is applied.
+}
+
+//  An influence map of h without sources. Decay should lie in (0,1]. A
+//  maxDistance of zero or less means sources influence every tile they
+//  can reach.
+func (h *Grid) NewInfluenceMap(cost StepCost, decay, maxDistance float64) *InfluenceMap {
+	if maxDistance <= 0 {
+		maxDistance = math.Inf(1)
+	}
+	return &InfluenceMap{
+		grid:        h,
+		cost:        cost,
+		decay:       decay,
+		maxDistance: maxDistance,
+		sources:     make(map[int]*influenceSource),
+		influence:   make([]float64, h.n*h.m),
+	}
+}
+
+//  The total influence on the tile at c. Returns 0 if c is not a tile of
+//  the grid.
+func (im *InfluenceMap) Influence(c hexcoords.Hex) float64 {
+	c, ok := im.grid.Wrap(c)
+	if !ok {
+		return 0
+	}
+	return im.influence[im.grid.tileSlot(c)]
+}
+
+//  The strength of the source at c, or 0 if there is none.
+func (im *InfluenceMap) Strength(c hexcoords.Hex) float64 {
+	c, ok := im.grid.Wrap(c)
+	if !ok {
+		return 0
+	}
+	if src := im.sources[im.grid.tileSlot(c)]; src != nil {
+		return src.strength
+	}
+	return 0
+}
+
+//  Set the strength of the source at c, adding a source if there is none.
+//  A strength of 0 removes the source.
+func (im *InfluenceMap) SetSource(c hexcoords.Hex, strength float64) {
+	c, ok := im.grid.Wrap(c)
+	if !ok {
+		return
+	}
+	var (
+		slot = im.grid.tileSlot(c)
+		src  = im.sources[slot]
+	)
+	switch {
+	case strength == 0:
+		if src != nil {
+			im.apply(src, -src.strength)
+			delete(im.sources, slot)
+		}
+	case src != nil:
+		im.apply(src, strength-src.strength)
+		src.strength = strength
+	default:
+		src = &influenceSource{strength: strength}
+		im.spread(slot, src)
+		im.apply(src, strength)
+		im.sources[slot] = src
+	}
+}
+
+//  Remove the source at c.
+func (im *InfluenceMap) RemoveSource(c hexcoords.Hex) {
+	im.SetSource(c, 0)
+}
+
+//  Move the source at from to the tile at to, keeping its strength. The
+//  strengths of sources at the same tile add. Does nothing if to is not a
+//  tile of the grid.
+func (im *InfluenceMap) MoveSource(from, to hexcoords.Hex) {
+	to, ok := im.grid.Wrap(to)
+	var strength = im.Strength(from)
+	if !ok || strength == 0 || im.grid.wrap(from) == to {
+		return
+	}
+	im.RemoveSource(from)
+	im.SetSource(to, im.Strength(to)+strength)
+}
+
+//  Recompute the influence of every source from scratch.
+func (im *InfluenceMap) Recompute() {
+	for slot := range im.influence {
+		im.influence[slot] = 0
+	}
+	for slot, src := range im.sources {
+		im.spread(slot, src)
+		im.apply(src, src.strength)
+	}
+}
+
+//  Add the weights of src, scaled by strength, to the influence of tiles.
+func (im *InfluenceMap) apply(src *influenceSource, strength float64) {
+	for i, slot := range src.slots {
+		im.influence[slot] += strength * src.weights[i]
+	}
+}
+
+//  Compute the weights of a source at slot with a Dijkstra search bounded
+//  by the maximum distance.
+func (im *InfluenceMap) spread(slot int, src *influenceSource) {
+	var (
+		h    = im.grid
+		dist = map[int]float64{slot: 0}
+		done = make(map[int]bool)
+		open = new(slotQueue)
+	)
+	src.slots, src.weights = src.slots[:0], src.weights[:0]
+	heap.Push(open, slotItem{slot, 0})
+	for open.Len() > 0 {
+		var item = heap.Pop(open).(slotItem)
+		if done[item.slot] {
+			continue
+		}
+		done[item.slot] = true
+		src.slots = append(src.slots, item.slot)
+		src.weights = append(src.weights, math.Pow(im.decay, item.priority))
+		var c = h.slotHex(item.slot)
+		h.eachAdjacent(c, func(adj hexcoords.Hex) {
+			var step = im.cost.between(c, adj)
+			if !passable(step) {
+				return
+			}
+			var (
+				adjSlot = h.tileSlot(adj)
+				d       = item.priority + step
+			)
+			if d > im.maxDistance {
+				return
+			}
+			if old, ok := dist[adjSlot]; ok && old <= d {
+				return
+			}
+			dist[adjSlot] = d
+			heap.Push(open, slotItem{adjSlot, d})
+		})
+	}
+}
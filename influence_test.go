@@ -0,0 +1,97 @@
+/*
+File: influence_test.go
+Created: Mon Oct 19 06:02:15 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInfluenceMap(T *testing.T) {
+	var (
+		h   = NewGrid(9, 9, 1, nil, nil, nil)
+		im  = h.NewInfluenceMap(nil, 0.5, 3)
+		src = hexcoords.Hex{0, 0}
+	)
+	im.SetSource(src, 8)
+	im.SetSource(hexcoords.Hex{3, 3}, -2)
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			var (
+				c        = hexcoords.Hex{u, v}
+				expected float64
+			)
+			if d := h.Distance(c, src); d <= 3 {
+				expected += 8 * math.Pow(0.5, float64(d))
+			}
+			if d := h.Distance(c, hexcoords.Hex{3, 3}); d <= 3 {
+				expected -= 2 * math.Pow(0.5, float64(d))
+			}
+			if i := im.Influence(c); math.Abs(i-expected) > 1e-9 {
+				T.Errorf("influence on %v is %v, expected %v", c, i, expected)
+			}
+		}
+	}
+	if s := im.Strength(src); s != 8 {
+		T.Errorf("strength %v", s)
+	}
+	im.RemoveSource(src)
+	im.RemoveSource(hexcoords.Hex{3, 3})
+	for slot, i := range im.influence {
+		if math.Abs(i) > 1e-9 {
+			T.Errorf("influence %v on %v without sources", i, h.slotHex(slot))
+		}
+	}
+}
+
+func TestInfluenceMapMoveOffGrid(T *testing.T) {
+	var (
+		h    = NewGrid(5, 5, 1, nil, nil, nil)
+		im   = h.NewInfluenceMap(nil, 0.5, 3)
+		edge = hexcoords.Hex{h.ColMax(), 0}
+	)
+	im.SetSource(edge, 4)
+	im.MoveSource(edge, hexcoords.Hex{h.ColMax() + 1, 0})
+	if s := im.Strength(edge); s != 4 {
+		T.Errorf("strength %v after moving off the grid", s)
+	}
+	if i := im.Influence(edge); i != 4 {
+		T.Errorf("influence %v after moving off the grid", i)
+	}
+}
+
+func TestInfluenceMapIncremental(T *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(3))
+		h   = NewWrappedGrid(12, 8, 1, WrapBoth, nil, nil, nil)
+	)
+	randomTerrain(h, rng)
+	var im = h.NewInfluenceMap(testTerrainCost(h), 0.8, 6)
+	for i := 0; i < 40; i++ {
+		var c = randomHex(h, rng)
+		switch rng.Intn(3) {
+		case 0:
+			im.SetSource(c, float64(rng.Intn(9)-4))
+		case 1:
+			im.MoveSource(c, c.Adjacent(tileDirections[rng.Intn(6)]))
+		default:
+			for slot := range im.sources {
+				im.MoveSource(h.slotHex(slot), randomHex(h, rng))
+				break
+			}
+		}
+	}
+	var incremental = append([]float64(nil), im.influence...)
+	im.Recompute()
+	for slot := range incremental {
+		if math.Abs(incremental[slot]-im.influence[slot]) > 1e-9 {
+			T.Errorf("influence on %v is %v, expected %v", h.slotHex(slot), incremental[slot], im.influence[slot])
+		}
+	}
+}
@@ -0,0 +1,638 @@
+/*
+File: mapfile.go
+Created: Mon Oct 19 04:58:31 UTC 2026
+*/
+
+/*
+Package mapfile reads and writes maps authored as text.
+
+A map file starts with a header describing the grid, followed by sections.
+Blank lines and lines starting with '#' are ignored outside the tiles
+section.
+
+	# A small island.
+	shape: rectangle
+	size: 5x3
+	orientation: flat
+	radius: 1
+	wrap: none
+
+	[legend]
+	~ = sea
+	f = forest
+	m = mountain
+
+	[tiles]
+	~ ~ ~ ~ ~
+	~ f m f ~
+	~ ~ f ~ ~
+
+	[edges]
+	(0,0)#0-1 = river
+
+	[vertices]
+	(0,0)#2 = town
+
+The shape is "rectangle", with a size of columns x rows, or "hexagon", with
+the size being the number of tiles from the center tile to the border. A
+hexagon of size r is drawn in a (2r+1)x(2r+1) rectangle; the tiles outside
+the hexagon must be written '-'. Only the "flat" orientation, flat sided to
+the north, is supported. A map has at most MaxTiles tiles. The radius and
+wrap (none, horizontal, vertical or both) of the grid are optional.
+
+The legend maps symbols to tile types. Symbols cannot be spaces, '#', '=',
+'[' or ']'. The tiles section has one line per row from north to south,
+with one symbol per column from west to east. Spaces between symbols are
+ignored. The symbol '-' marks a tile without a type. The edges and
+vertices sections annotate edges and vertices with text.
+*/
+package mapfile
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//  The outline of the tiles of a map.
+type Shape int
+
+const (
+	Rectangle Shape = iota
+	Hexagon
+)
+
+var shapeNames = []string{
+	Rectangle: "rectangle",
+	Hexagon:   "hexagon",
+}
+
+func (s Shape) String() string {
+	if s < 0 || int(s) >= len(shapeNames) {
+		return "unknown"
+	}
+	return shapeNames[s]
+}
+
+//  The symbol of tiles without a type.
+const NoType = '-'
+
+//  The largest number of tiles in a map, including those outside a hexagon.
+//  Larger maps are rejected before their grid is allocated.
+const MaxTiles = 1 << 22
+
+//  A Map is the content of a map file. Tile types and annotations are
+//  keyed by coordinates of the grid returned by Grid.
+type Map struct {
+	Shape Shape
+
+	//  The number of columns and rows of a rectangle. For a hexagon, Size
+	//  is the number of tiles from the center to the border.
+	Columns, Rows int
+	Size          int
+
+	//  The tile radius of the grid. Zero means 1.
+	Radius float64
+	Wrap   hexgrid.Topology
+
+	Legend   map[rune]string
+	Tiles    map[hexcoords.Hex]string
+	Edges    map[hexcoords.Edge]string
+	Vertices map[hexcoords.Vertex]string
+}
+
+//  An error in a map file.
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (err *SyntaxError) Error() string {
+	return fmt.Sprintf("mapfile: line %d: %s", err.Line, err.Msg)
+}
+
+var wrapNames = []string{
+	hexgrid.Bounded:        "none",
+	hexgrid.WrapHorizontal: "horizontal",
+	hexgrid.WrapVertical:   "vertical",
+	hexgrid.WrapBoth:       "both",
+}
+
+//  The number of columns and rows of the grid of m.
+func (m *Map) dimensions() (int, int) {
+	if m.Shape == Hexagon {
+		return 2*m.Size + 1, 2*m.Size + 1
+	}
+	return m.Columns, m.Rows
+}
+
+//  Returns true if the tile at c is within the shape of m, whose grid is g.
+func (m *Map) inShape(g *hexgrid.Grid, c hexcoords.Hex) bool {
+	if !g.WithinBounds(c) {
+		return false
+	}
+	return m.Shape != Hexagon || c.Distance(hexcoords.Hex{}) <= m.Size
+}
+
+//  Check the header fields of m, returning a description of the first
+//  problem found.
+func (m *Map) check() string {
+	var n, rows = m.dimensions()
+	switch {
+	case m.Shape != Rectangle && m.Shape != Hexagon:
+		return "unknown shape"
+	case m.Shape == Hexagon && m.Wrap != hexgrid.Bounded:
+		return "a hexagon cannot wrap"
+	case m.Wrap < 0 || int(m.Wrap) >= len(wrapNames):
+		return "unknown wrap"
+	case n <= 0 || rows <= 0 || m.Size < 0:
+		return "invalid size"
+	case n > MaxTiles/rows:
+		return "too many tiles"
+	case m.Radius < 0:
+		return "invalid radius"
+	case m.Wrap == hexgrid.Bounded && (n%2 == 0 || rows%2 == 0):
+		return "a map without wrapping needs an odd number of columns and rows"
+	case m.Wrap&hexgrid.WrapHorizontal != 0 && n%2 == 1:
+		return "a map wrapping horizontally needs an even number of columns"
+	}
+	return ""
+}
+
+//  A new grid with the shape of m whose tiles hold their type names and
+//  whose edges and vertices hold their annotations, as strings. Tiles
+//  without a type, and edges and vertices without annotations, hold nil.
+//  Panics if the header of m is invalid (see Read).
+func (m *Map) Grid() *hexgrid.Grid {
+	if msg := m.check(); msg != "" {
+		panic("mapfile: " + msg)
+	}
+	var (
+		n, rows = m.dimensions()
+		r       = m.Radius
+	)
+	if r == 0 {
+		r = 1
+	}
+	var g = hexgrid.NewWrappedGrid(n, rows, r, m.Wrap, nil, nil, nil)
+	for c, t := range m.Tiles {
+		g.SetTileValue(c, t)
+	}
+	for e, note := range m.Edges {
+		g.SetEdgeValue(e, note)
+	}
+	for vc, note := range m.Vertices {
+		g.SetVertexValue(vc, note)
+	}
+	return g
+}
+
+//  A map of g with the given legend. Tile values are written as the symbol
+//  the legend gives their type, which is the value itself if it is a string
+//  and its fmt.Sprint formatting otherwise. Tiles with nil values are
+//  written '-'. If legend is nil, symbols are chosen from the first letters
+//  of the types. Edge and vertex values are written as annotations.
+//
+//  A grid with odd dimensions whose non-nil tiles form a hexagon centered
+//  on the origin is written as a Hexagon map.
+func FromGrid(g *hexgrid.Grid, legend map[rune]string) (*Map, error) {
+	var m = &Map{
+		Shape:    Rectangle,
+		Columns:  g.NumCols(),
+		Rows:     g.NumRows(),
+		Radius:   g.Radius(),
+		Wrap:     g.Topology(),
+		Legend:   legend,
+		Tiles:    make(map[hexcoords.Hex]string),
+		Edges:    make(map[hexcoords.Edge]string),
+		Vertices: make(map[hexcoords.Vertex]string),
+	}
+	var symbols = make(map[string]rune)
+	for sym, t := range legend {
+		symbols[t] = sym
+	}
+	var types []string
+	eachTile(g, func(c hexcoords.Hex) {
+		if value := g.TileValue(c); value != nil {
+			var t = valueString(value)
+			if _, ok := symbols[t]; !ok && legend == nil {
+				symbols[t] = 0
+				types = append(types, t)
+			}
+			m.Tiles[c] = t
+		}
+		for _, e := range c.Edges(hex.NilDirection) {
+			if value := g.EdgeValue(e); value != nil {
+				var canon, _ = g.CanonicalEdge(e)
+				m.Edges[canon] = valueString(value)
+			}
+		}
+		for _, vc := range c.Vertices(hex.NilDirection) {
+			if value := g.VertexValue(vc); value != nil {
+				var canon, _ = g.CanonicalVertex(vc)
+				m.Vertices[canon] = valueString(value)
+			}
+		}
+	})
+	if legend == nil {
+		m.Legend = chooseSymbols(types)
+	}
+	for c, t := range m.Tiles {
+		if _, ok := symbols[t]; !ok {
+			return nil, fmt.Errorf("mapfile: no symbol for the type %q of tile %v", t, c)
+		}
+	}
+	if m.Columns == m.Rows && m.Wrap == hexgrid.Bounded && m.Columns%2 == 1 {
+		m.Shape, m.Size = Hexagon, m.Columns/2
+		for c := range m.Tiles {
+			if !m.inShape(g, c) {
+				m.Shape, m.Size = Rectangle, 0
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+//  A legend for types, preferring the first letter of each type.
+func chooseSymbols(types []string) map[rune]string {
+	sort.Strings(types)
+	var (
+		legend = make(map[rune]string)
+		taken  = func(r rune) bool {
+			var _, ok = legend[r]
+			return ok || r == NoType || !validSymbol(r)
+		}
+	)
+	for _, t := range types {
+		var sym rune
+		for _, r := range t {
+			if !taken(unicode.ToLower(r)) {
+				sym = unicode.ToLower(r)
+				break
+			}
+			if !taken(unicode.ToUpper(r)) {
+				sym = unicode.ToUpper(r)
+				break
+			}
+		}
+		for r := 'a'; sym == 0; r++ {
+			if !taken(r) {
+				sym = r
+			}
+		}
+		legend[sym] = t
+	}
+	return legend
+}
+
+//  Returns true if r can be used as a symbol.
+func validSymbol(r rune) bool {
+	switch r {
+	case '#', '=', '[', ']':
+		return false
+	}
+	return unicode.IsGraphic(r) && !unicode.IsSpace(r)
+}
+
+func valueString(value hexgrid.Value) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+//  Call fn with each tile of g, column by column.
+func eachTile(g *hexgrid.Grid, fn func(hexcoords.Hex)) {
+	for u := g.ColMin(); u <= g.ColMax(); u++ {
+		for v := g.RowMin(); v <= g.RowMax(); v++ {
+			fn(hexcoords.Hex{u, v})
+		}
+	}
+}
+
+//  Write m to w in the map file format.
+func (m *Map) Write(w io.Writer) error {
+	if msg := m.check(); msg != "" {
+		return fmt.Errorf("mapfile: %s", msg)
+	}
+	var (
+		bw      = bufio.NewWriter(w)
+		symbols = make(map[string]rune)
+		legend  []rune
+	)
+	for sym, t := range m.Legend {
+		if !validSymbol(sym) || sym == NoType {
+			return fmt.Errorf("mapfile: invalid symbol %q", sym)
+		}
+		symbols[t] = sym
+		legend = append(legend, sym)
+	}
+	sort.Slice(legend, func(i, j int) bool { return legend[i] < legend[j] })
+
+	fmt.Fprintf(bw, "shape: %v\n", m.Shape)
+	if m.Shape == Hexagon {
+		fmt.Fprintf(bw, "size: %d\n", m.Size)
+	} else {
+		fmt.Fprintf(bw, "size: %dx%d\n", m.Columns, m.Rows)
+	}
+	fmt.Fprintln(bw, "orientation: flat")
+	if m.Radius != 0 {
+		fmt.Fprintf(bw, "radius: %s\n", strconv.FormatFloat(m.Radius, 'g', -1, 64))
+	}
+	fmt.Fprintf(bw, "wrap: %s\n", wrapNames[m.Wrap])
+
+	fmt.Fprintln(bw, "\n[legend]")
+	for _, sym := range legend {
+		fmt.Fprintf(bw, "%c = %s\n", sym, m.Legend[sym])
+	}
+
+	fmt.Fprintln(bw, "\n[tiles]")
+	var (
+		n, rows = m.dimensions()
+		g       = hexgrid.NewCompactWrappedGrid(n, rows, 1, m.Wrap, nil, nil, nil)
+	)
+	for v := g.RowMax(); v >= g.RowMin(); v-- {
+		var row = make([]string, 0, g.NumCols())
+		for u := g.ColMin(); u <= g.ColMax(); u++ {
+			var sym = NoType
+			if t, ok := m.Tiles[hexcoords.Hex{u, v}]; ok {
+				if sym, ok = symbols[t]; !ok {
+					return fmt.Errorf("mapfile: no symbol for tile type %q", t)
+				}
+			}
+			row = append(row, string(sym))
+		}
+		fmt.Fprintln(bw, strings.Join(row, " "))
+	}
+
+	if len(m.Edges) > 0 {
+		var edges []hexcoords.Edge
+		for e := range m.Edges {
+			edges = append(edges, e)
+		}
+		sort.Slice(edges, func(i, j int) bool { return edges[i].String() < edges[j].String() })
+		fmt.Fprintln(bw, "\n[edges]")
+		for _, e := range edges {
+			fmt.Fprintf(bw, "%v = %s\n", e, m.Edges[e])
+		}
+	}
+	if len(m.Vertices) > 0 {
+		var vertices []hexcoords.Vertex
+		for vc := range m.Vertices {
+			vertices = append(vertices, vc)
+		}
+		sort.Slice(vertices, func(i, j int) bool { return vertices[i].String() < vertices[j].String() })
+		fmt.Fprintln(bw, "\n[vertices]")
+		for _, vc := range vertices {
+			fmt.Fprintf(bw, "%v = %s\n", vc, m.Vertices[vc])
+		}
+	}
+	return bw.Flush()
+}
+
+//  Read a map file.
+func Read(r io.Reader) (*Map, error) {
+	var (
+		p       = parser{m: &Map{Legend: make(map[rune]string)}}
+		scanner = bufio.NewScanner(r)
+	)
+	for scanner.Scan() {
+		p.line++
+		if err := p.parseLine(strings.TrimRight(scanner.Text(), " \t\r")); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := p.finish(); err != nil {
+		return nil, err
+	}
+	return p.m, nil
+}
+
+//  The state of Read.
+type parser struct {
+	m       *Map
+	line    int
+	section string
+	header  map[string]bool
+	grid    *hexgrid.Grid
+	rows    [][]rune
+	rowLine []int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{p.line, fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseLine(line string) error {
+	var trimmed = strings.TrimSpace(line)
+	if trimmed == "" || (p.section != "tiles" && strings.HasPrefix(trimmed, "#")) {
+		return nil
+	}
+	if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+		return p.startSection(trimmed[1 : len(trimmed)-1])
+	}
+	switch p.section {
+	case "":
+		return p.parseHeader(trimmed)
+	case "legend":
+		return p.parseLegend(trimmed)
+	case "tiles":
+		var row []rune
+		for _, r := range trimmed {
+			if !unicode.IsSpace(r) {
+				row = append(row, r)
+			}
+		}
+		p.rows = append(p.rows, row)
+		p.rowLine = append(p.rowLine, p.line)
+		return nil
+	}
+	var (
+		key, value, ok = splitPair(trimmed, "=")
+		err            error
+	)
+	if !ok {
+		return p.errorf("expected coordinates = annotation")
+	}
+	switch p.section {
+	case "edges":
+		var e hexcoords.Edge
+		if e, err = hexcoords.ParseEdge(key); err != nil {
+			return p.errorf("invalid edge %q", key)
+		}
+		var canon, ok = p.grid.CanonicalEdge(e)
+		if !ok || canon.IsNil() {
+			return p.errorf("edge %v is not part of the map", e)
+		}
+		p.m.Edges[canon] = value
+	case "vertices":
+		var vc hexcoords.Vertex
+		if vc, err = hexcoords.ParseVertex(key); err != nil {
+			return p.errorf("invalid vertex %q", key)
+		}
+		var canon, ok = p.grid.CanonicalVertex(vc)
+		if !ok {
+			return p.errorf("vertex %v is not part of the map", vc)
+		}
+		p.m.Vertices[canon] = value
+	}
+	return nil
+}
+
+//  Split s around the first sep, trimming spaces from both parts.
+func splitPair(s, sep string) (string, string, bool) {
+	var i = strings.Index(s, sep)
+	if i < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(sep):]), true
+}
+
+func (p *parser) startSection(name string) error {
+	switch name {
+	case "legend", "tiles", "edges", "vertices":
+	default:
+		return p.errorf("unknown section [%s]", name)
+	}
+	if p.section == "" {
+		if !p.header["size"] {
+			return p.errorf("missing size")
+		}
+		if msg := p.m.check(); msg != "" {
+			return p.errorf("%s", msg)
+		}
+		var n, rows = p.m.dimensions()
+		p.grid = hexgrid.NewCompactWrappedGrid(n, rows, 1, p.m.Wrap, nil, nil, nil)
+		p.m.Tiles = make(map[hexcoords.Hex]string)
+		p.m.Edges = make(map[hexcoords.Edge]string)
+		p.m.Vertices = make(map[hexcoords.Vertex]string)
+	}
+	p.section = name
+	return nil
+}
+
+func (p *parser) parseHeader(line string) error {
+	var key, value, ok = splitPair(line, ":")
+	if !ok {
+		return p.errorf("expected key: value")
+	}
+	if p.header == nil {
+		p.header = make(map[string]bool)
+	}
+	if p.header[key] {
+		return p.errorf("duplicate %s", key)
+	}
+	p.header[key] = true
+	switch key {
+	case "shape":
+		for s, name := range shapeNames {
+			if name == value {
+				p.m.Shape = Shape(s)
+				return nil
+			}
+		}
+		return p.errorf("unknown shape %q", value)
+	case "size":
+		if cols, rows, ok := splitPair(value, "x"); ok {
+			var n, err = strconv.Atoi(cols)
+			if err == nil {
+				p.m.Rows, err = strconv.Atoi(rows)
+			}
+			if err != nil {
+				return p.errorf("invalid size %q", value)
+			}
+			p.m.Columns = n
+			return nil
+		}
+		var size, err = strconv.Atoi(value)
+		if err != nil {
+			return p.errorf("invalid size %q", value)
+		}
+		p.m.Size = size
+		return nil
+	case "orientation":
+		if value != "flat" {
+			return p.errorf("unsupported orientation %q", value)
+		}
+		return nil
+	case "radius":
+		var r, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return p.errorf("invalid radius %q", value)
+		}
+		p.m.Radius = r
+		return nil
+	case "wrap":
+		for w, name := range wrapNames {
+			if name == value {
+				p.m.Wrap = hexgrid.Topology(w)
+				return nil
+			}
+		}
+		return p.errorf("unknown wrap %q", value)
+	}
+	return p.errorf("unknown header %q", key)
+}
+
+func (p *parser) parseLegend(line string) error {
+	var sym, size = utf8.DecodeRuneInString(line)
+	var rest = strings.TrimSpace(line[size:])
+	if !strings.HasPrefix(rest, "=") {
+		return p.errorf("expected symbol = type")
+	}
+	if !validSymbol(sym) || sym == NoType {
+		return p.errorf("invalid symbol %q", sym)
+	}
+	if _, dup := p.m.Legend[sym]; dup {
+		return p.errorf("duplicate symbol %q", sym)
+	}
+	p.m.Legend[sym] = strings.TrimSpace(rest[1:])
+	return nil
+}
+
+//  Check the header and tile rows once the whole file is read.
+func (p *parser) finish() error {
+	if p.grid == nil {
+		if err := p.startSection("tiles"); err != nil {
+			return err
+		}
+	}
+	var n, rows = p.m.dimensions()
+	if len(p.rows) != rows {
+		return p.errorf("%d rows of tiles, expected %d", len(p.rows), rows)
+	}
+	for i, row := range p.rows {
+		p.line = p.rowLine[i]
+		if len(row) != n {
+			return p.errorf("%d tiles in row, expected %d", len(row), n)
+		}
+		for j, sym := range row {
+			var c = hexcoords.Hex{p.grid.ColMin() + j, p.grid.RowMax() - i}
+			if sym == NoType {
+				continue
+			}
+			var t, ok = p.m.Legend[sym]
+			if !ok {
+				return p.errorf("symbol %q is not in the legend", sym)
+			}
+			if !p.m.inShape(p.grid, c) {
+				return p.errorf("tile %v is outside the hexagon", c)
+			}
+			p.m.Tiles[c] = t
+		}
+	}
+	return nil
+}
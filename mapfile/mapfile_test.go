@@ -0,0 +1,183 @@
+/*
+File: mapfile_test.go
+Created: Mon Oct 19 04:58:31 UTC 2026
+*/
+
+package mapfile
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const island = `# A small island.
+shape: rectangle
+size: 5x3
+orientation: flat
+radius: 2
+wrap: none
+
+[legend]
+~ = sea
+f = forest
+m = mountain
+
+[tiles]
+~ ~ ~ ~ ~
+~ f m f ~
+~ ~ f ~ -
+
+[edges]
+(0,0)#3-4 = river
+
+[vertices]
+(0,0)#2 = town
+`
+
+func TestRead(T *testing.T) {
+	var m, err = Read(strings.NewReader(island))
+	if err != nil {
+		T.Fatal(err)
+	}
+	var g = m.Grid()
+	if g.NumCols() != 5 || g.NumRows() != 3 || g.Radius() != 2 {
+		T.Errorf("grid %dx%d radius %v", g.NumCols(), g.NumRows(), g.Radius())
+	}
+	for _, test := range []struct {
+		c hexcoords.Hex
+		t hexgrid.Value
+	}{
+		{hexcoords.Hex{-2, 1}, "sea"},
+		{hexcoords.Hex{0, 0}, "mountain"},
+		{hexcoords.Hex{-1, 0}, "forest"},
+		{hexcoords.Hex{0, -1}, "forest"},
+		{hexcoords.Hex{2, -1}, nil},
+	} {
+		if t := g.TileValue(test.c); t != test.t {
+			T.Errorf("tile %v type %v, expected %v", test.c, t, test.t)
+		}
+	}
+	if note := g.EdgeValue(hexcoords.Edge{0, 1, 0, 1}); note != "river" {
+		T.Errorf("edge annotation %v", note)
+	}
+	if note := g.VertexValue(hexcoords.Vertex{1, 0, 0}); note != "town" {
+		T.Errorf("vertex annotation %v", note)
+	}
+}
+
+func TestRoundTrip(T *testing.T) {
+	var m, err = Read(strings.NewReader(island))
+	if err != nil {
+		T.Fatal(err)
+	}
+	m2, err := FromGrid(m.Grid(), m.Legend)
+	if err != nil {
+		T.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = m2.Write(&buf); err != nil {
+		T.Fatal(err)
+	}
+	var expected = `shape: rectangle
+size: 5x3
+orientation: flat
+radius: 2
+wrap: none
+
+[legend]
+f = forest
+m = mountain
+~ = sea
+
+[tiles]
+~ ~ ~ ~ ~
+~ f m f ~
+~ ~ f ~ -
+
+[edges]
+(0,1)#0-1 = river
+
+[vertices]
+(1,0)#0 = town
+`
+	if buf.String() != expected {
+		T.Errorf("written map:\n%s", buf.String())
+	}
+}
+
+func TestHexagon(T *testing.T) {
+	var g = hexgrid.NewGrid(5, 5, 1, nil, nil, nil)
+	for u := g.ColMin(); u <= g.ColMax(); u++ {
+		for v := g.RowMin(); v <= g.RowMax(); v++ {
+			var c = hexcoords.Hex{u, v}
+			if c.Distance(hexcoords.Hex{}) <= 2 {
+				g.SetTileValue(c, "grass")
+			}
+		}
+	}
+	g.SetTileValue(hexcoords.Hex{0, 0}, "Garden")
+	var m, err = FromGrid(g, nil)
+	if err != nil {
+		T.Fatal(err)
+	}
+	if m.Shape != Hexagon || m.Size != 2 {
+		T.Errorf("shape %v size %d", m.Shape, m.Size)
+	}
+	if m.Legend['g'] != "Garden" || m.Legend['G'] != "grass" {
+		T.Errorf("legend %v", m.Legend)
+	}
+	var buf bytes.Buffer
+	if err = m.Write(&buf); err != nil {
+		T.Fatal(err)
+	}
+	m2, err := Read(&buf)
+	if err != nil {
+		T.Fatal(err)
+	}
+	var g2 = m2.Grid()
+	for u := g.ColMin(); u <= g.ColMax(); u++ {
+		for v := g.RowMin(); v <= g.RowMax(); v++ {
+			var c = hexcoords.Hex{u, v}
+			if g.TileValue(c) != g2.TileValue(c) {
+				T.Errorf("tile %v read as %v, expected %v", c, g2.TileValue(c), g.TileValue(c))
+			}
+		}
+	}
+}
+
+func TestReadErrors(T *testing.T) {
+	var header = "shape: rectangle\nsize: 3x1\n"
+	for _, test := range []struct {
+		text string
+		line int
+	}{
+		{"shape: circle\n", 1},
+		{"size: 3x1\norientation: pointy\n", 2},
+		{"size: 4x1\n[tiles]\n", 2},
+		{"size: 3x1\nsize: 3x1\n", 2},
+		{"size: 5x3abc\nwrap: none\n", 1},
+		{"size: 5xx3\nwrap: none\n", 1},
+		{"size: 4097x4097\n[legend]\n[tiles]\n", 2},
+		{"shape: hexagon\nsize: 1100\n[legend]\n[tiles]\n", 3},
+		{header + "[legend]\n[ = x\nb = y\n", 4},
+		{header + "[legend]\n] = x\nb = y\n", 4},
+		{"[tiles]\n", 1},
+		{header + "[legend]\na forest\n", 4},
+		{header + "[legend]\na = x\na = y\n", 5},
+		{header + "[tiles]\na a a\n", 4},
+		{header + "[legend]\na = x\n[tiles]\na a\n", 6},
+		{header + "[legend]\na = x\n[tiles]\na a a\na a a\n", 7},
+		{header + "[edges]\n(0,0)#0-3 = wall\n", 4},
+		{header + "[vertices]\n(5,0)#1 = town\n", 4},
+		{"shape: hexagon\nsize: 1\n[legend]\na = x\n[tiles]\na - -\n- a -\n- - -\n", 6},
+	} {
+		var _, err = Read(strings.NewReader(test.text))
+		if serr, ok := err.(*SyntaxError); !ok || serr.Line != test.line {
+			T.Errorf("%q: error %v, expected line %d", test.text, err, test.line)
+		}
+	}
+}
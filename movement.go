@@ -0,0 +1,219 @@
+/*
+File: movement.go
+Created: Mon Oct 19 06:40:52 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"container/heap"
+	"sort"
+)
+
+//  MoveRules describe how a unit moves over a grid, as in a tactics game.
+//  The cost of a step is the cost of entering the destination tile plus the
+//  cost of crossing the edge between the tiles.
+type MoveRules struct {
+	//  The cost of entering a tile. Nil means 1. A negative or infinite
+	//  cost makes the tile impassable.
+	Enter func(t *Tile) float64
+
+	//  The additional cost of crossing an edge, such as a river. Nil means
+	//  0. A negative or infinite cost makes the edge impassable, like a
+	//  wall.
+	Cross func(e *Edge) float64
+
+	//  Returns true if a tile is occupied by an enemy. Enemy tiles cannot
+	//  be entered, and the tiles adjacent to them are in the enemies' zone
+	//  of control: a unit entering such a tile must stop there. A unit may
+	//  leave a zone of control it starts in. Nil means there are no
+	//  enemies.
+	Enemy func(t *Tile) bool
+}
+
+//  The tiles a unit can reach in one turn, computed by MovementRange.
+type MoveRange struct {
+	grid      *Grid
+	start     int
+	remaining map[int]float64 // By tile slot.
+	parent    map[int]int
+	stopped   map[int]bool // Tiles in a zone of control.
+}
+
+//  The cost of stepping from the tile at from to the adjacent tile at to
+//  in direction k, which are within the bounds of h.
+func (rules *MoveRules) stepCost(h *Grid, from hexcoords.Hex, k int, to hexcoords.Hex) float64 {
+	var cost = 1.0
+	if rules.Enter != nil {
+		cost = rules.Enter(h.GetTile(to))
+	}
+	if rules.Cross != nil && passable(cost) {
+		// Side k of a tile runs from corner k to corner k+1.
+		var cross = rules.Cross(h.GetEdge(hexcoords.Edge{from.U, from.V, k, (k + 1) % 6}))
+		if !passable(cross) {
+			return -1
+		}
+		cost += cross
+	}
+	return cost
+}
+
+//  The answers of rules.Enemy for the tiles of h, by tile slot. Each tile
+//  is asked about by up to seven of its neighbours, and GetTile allocates
+//  on a compact grid.
+type enemyCache struct {
+	h     *Grid
+	rules *MoveRules
+	known map[int]bool
+}
+
+//  Returns true if the tile at c, within the bounds of h, holds an enemy.
+func (ec *enemyCache) enemy(c hexcoords.Hex) bool {
+	if ec.rules.Enemy == nil {
+		return false
+	}
+	var slot = ec.h.tileSlot(c)
+	if e, ok := ec.known[slot]; ok {
+		return e
+	}
+	var e = ec.rules.Enemy(ec.h.GetTile(c))
+	ec.known[slot] = e
+	return e
+}
+
+//  Returns true if the tile at c is in the zone of control of an enemy.
+func (ec *enemyCache) controlled(c hexcoords.Hex) bool {
+	if ec.rules.Enemy == nil {
+		return false
+	}
+	var zoc = false
+	ec.h.eachAdjacent(c, func(adj hexcoords.Hex) {
+		zoc = zoc || ec.enemy(adj)
+	})
+	return zoc
+}
+
+//  Find the tiles a unit at start with the given movement points can reach
+//  under rules, with the cheapest path to each. Paths cross wrapping
+//  borders. Returns nil if start is not a tile of h.
+func (h *Grid) MovementRange(start hexcoords.Hex, points float64, rules MoveRules) *MoveRange {
+	start, ok := h.Wrap(start)
+	if !ok {
+		return nil
+	}
+	var (
+		slot = h.tileSlot(start)
+		r    = &MoveRange{
+			grid:      h,
+			start:     slot,
+			remaining: map[int]float64{slot: points},
+			parent:    map[int]int{slot: -1},
+			stopped:   make(map[int]bool),
+		}
+		done    = make(map[int]bool)
+		open    = new(slotQueue)
+		enemies = &enemyCache{h: h, rules: &rules, known: make(map[int]bool)}
+	)
+	heap.Push(open, slotItem{slot, 0})
+	for open.Len() > 0 {
+		var item = heap.Pop(open).(slotItem)
+		if done[item.slot] {
+			continue
+		}
+		done[item.slot] = true
+		if r.stopped[item.slot] {
+			continue
+		}
+		var c = h.slotHex(item.slot)
+		for k, dir := range tileDirections {
+			var adj, ok = h.Wrap(c.Adjacent(dir))
+			if !ok {
+				continue
+			}
+			var adjSlot = h.tileSlot(adj)
+			if done[adjSlot] || enemies.enemy(adj) {
+				continue
+			}
+			var step = rules.stepCost(h, c, k, adj)
+			if !passable(step) {
+				continue
+			}
+			var spent = item.priority + step
+			if spent > points {
+				continue
+			}
+			if rem, ok := r.remaining[adjSlot]; ok && rem >= points-spent {
+				continue
+			}
+			r.remaining[adjSlot] = points - spent
+			r.parent[adjSlot] = item.slot
+			if _, ok := r.stopped[adjSlot]; !ok {
+				r.stopped[adjSlot] = enemies.controlled(adj)
+			}
+			heap.Push(open, slotItem{adjSlot, spent})
+		}
+	}
+	return r
+}
+
+//  Returns true if the tile at c can be reached.
+func (r *MoveRange) Contains(c hexcoords.Hex) bool {
+	var _, ok = r.Remaining(c)
+	return ok
+}
+
+//  The movement points left after moving to the tile at c along the path
+//  returned by Path. A unit stopping in a zone of control has no points
+//  left. The second return value is false if c cannot be reached.
+func (r *MoveRange) Remaining(c hexcoords.Hex) (float64, bool) {
+	c, ok := r.grid.Wrap(c)
+	if !ok {
+		return 0, false
+	}
+	var slot = r.grid.tileSlot(c)
+	rem, ok := r.remaining[slot]
+	if r.stopped[slot] {
+		rem = 0
+	}
+	return rem, ok
+}
+
+//  Returns true if a unit moving to the tile at c must stop there because
+//  it is in an enemy's zone of control.
+func (r *MoveRange) Stopped(c hexcoords.Hex) bool {
+	c, ok := r.grid.Wrap(c)
+	return ok && r.stopped[r.grid.tileSlot(c)]
+}
+
+//  The cheapest path from the start tile to the tile at c, including both
+//  ends. Returns nil if c cannot be reached.
+func (r *MoveRange) Path(c hexcoords.Hex) []hexcoords.Hex {
+	c, ok := r.grid.Wrap(c)
+	if !ok || !r.Contains(c) {
+		return nil
+	}
+	var path []hexcoords.Hex
+	for slot := r.grid.tileSlot(c); slot >= 0; slot = r.parent[slot] {
+		path = append(path, r.grid.slotHex(slot))
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+//  The reachable tiles, including the start tile, in column-major order.
+func (r *MoveRange) Tiles() []hexcoords.Hex {
+	var slots = make([]int, 0, len(r.remaining))
+	for slot := range r.remaining {
+		slots = append(slots, slot)
+	}
+	sort.Ints(slots)
+	var tiles = make([]hexcoords.Hex, len(slots))
+	for i, slot := range slots {
+		tiles[i] = r.grid.slotHex(slot)
+	}
+	return tiles
+}
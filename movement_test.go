@@ -0,0 +1,132 @@
+/*
+File: movement_test.go
+Created: Mon Oct 19 06:40:52 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestMovementRangeUniform(T *testing.T) {
+	var (
+		h     = NewGrid(9, 9, 1, nil, nil, nil)
+		start = hexcoords.Hex{0, 0}
+		r     = h.MovementRange(start, 2, MoveRules{})
+	)
+	if n := len(r.Tiles()); n != 19 {
+		T.Errorf("%d tiles in range 2", n)
+	}
+	for _, c := range r.Tiles() {
+		var rem, ok = r.Remaining(c)
+		if !ok || rem != float64(2-h.Distance(start, c)) {
+			T.Errorf("%v: remaining %v %v", c, rem, ok)
+		}
+		if path := r.Path(c); len(path) != h.Distance(start, c)+1 || path[0] != start || path[len(path)-1] != c {
+			T.Errorf("%v: path %v", c, path)
+		}
+	}
+	if r.Contains(hexcoords.Hex{3, 0}) || r.Path(hexcoords.Hex{3, 0}) != nil {
+		T.Errorf("tile at distance 3 in range")
+	}
+	if h.MovementRange(hexcoords.Hex{10, 0}, 2, MoveRules{}) != nil {
+		T.Errorf("range from outside the grid")
+	}
+}
+
+func TestMovementRangeCosts(T *testing.T) {
+	var h = NewGrid(9, 9, 1, 1.0, nil, nil)
+	h.SetTileValue(hexcoords.Hex{-1, 0}, 3.0)
+	h.SetTileValue(hexcoords.Hex{-1, -1}, -1.0)
+	h.SetEdgeValue(hexcoords.Edge{0, 0, 3, 4}, 2.0)  // A river north of (0,0).
+	h.SetEdgeValue(hexcoords.Edge{0, 0, 1, 2}, -1.0) // A wall to the southeast.
+	var rules = MoveRules{
+		Enter: func(t *Tile) float64 { return t.Value.(float64) },
+		Cross: func(e *Edge) float64 {
+			if e.Value == nil {
+				return 0
+			}
+			return e.Value.(float64)
+		},
+	}
+	var r = h.MovementRange(hexcoords.Hex{0, 0}, 2, rules)
+	for _, test := range []struct {
+		c         hexcoords.Hex
+		remaining float64
+		ok        bool
+		path      int
+	}{
+		{hexcoords.Hex{0, 1}, 0, true, 3}, // Around the river.
+		{hexcoords.Hex{1, 0}, 1, true, 2},
+		{hexcoords.Hex{1, -1}, 0, true, 3}, // Around the wall.
+		{hexcoords.Hex{-1, 0}, 0, false, 0},
+		{hexcoords.Hex{-1, -1}, 0, false, 0},
+	} {
+		var rem, ok = r.Remaining(test.c)
+		if rem != test.remaining || ok != test.ok || len(r.Path(test.c)) != test.path {
+			T.Errorf("%v: remaining %v %v path %v", test.c, rem, ok, r.Path(test.c))
+		}
+	}
+}
+
+func TestMovementRangeZoneOfControl(T *testing.T) {
+	var (
+		h     = NewGrid(9, 9, 1, nil, nil, nil)
+		enemy = hexcoords.Hex{0, 2}
+		start = hexcoords.Hex{0, 0}
+		rules = MoveRules{Enemy: func(t *Tile) bool { return t.Value == "enemy" }}
+	)
+	h.SetTileValue(enemy, "enemy")
+	var r = h.MovementRange(start, 4, rules)
+	if r.Contains(enemy) {
+		T.Errorf("enemy tile reachable")
+	}
+	if rem, ok := r.Remaining(hexcoords.Hex{0, 1}); !ok || rem != 0 || !r.Stopped(hexcoords.Hex{0, 1}) {
+		T.Errorf("zone of control tile remaining %v %v", rem, ok)
+	}
+	for _, c := range r.Tiles() {
+		var path = r.Path(c)
+		for _, step := range path[1:imax(1, len(path)-1)] {
+			if r.Stopped(step) {
+				T.Errorf("path %v continues through a zone of control", path)
+			}
+		}
+	}
+	// The tile north of the enemy is reached around its zone of control.
+	if path := r.Path(hexcoords.Hex{0, 3}); len(path) != 0 {
+		if len(path)-1 <= h.Distance(start, hexcoords.Hex{0, 3}) {
+			T.Errorf("path %v through the zone of control", path)
+		}
+	}
+
+	// A unit may leave the zone of control it starts in.
+	r = h.MovementRange(hexcoords.Hex{0, 1}, 1, rules)
+	if !r.Contains(start) || r.Stopped(hexcoords.Hex{0, 1}) {
+		T.Errorf("unit cannot leave its zone of control")
+	}
+}
+
+//  Each tile is asked about at most once, however many of its neighbours
+//  are reached.
+func TestMovementRangeEnemyCalls(T *testing.T) {
+	var (
+		h     = NewCompactGrid(9, 9, 1, nil, nil, nil)
+		calls = make(map[hexcoords.Hex]int)
+		rules = MoveRules{Enemy: func(t *Tile) bool {
+			calls[t.Hex]++
+			return t.Hex == hexcoords.Hex{2, 0}
+		}}
+	)
+	var r = h.MovementRange(hexcoords.Hex{0, 0}, 3, rules)
+	for c, n := range calls {
+		if n != 1 {
+			T.Errorf("%v: enemy tested %d times", c, n)
+		}
+	}
+	if !r.Stopped(hexcoords.Hex{1, 0}) || r.Contains(hexcoords.Hex{2, 0}) {
+		T.Errorf("zone of control of the enemy at (2,0) ignored")
+	}
+}
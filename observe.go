@@ -0,0 +1,196 @@
+/*
+File: observe.go
+Created: Mon Oct 19 00:12:37 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"sync/atomic"
+)
+
+//  A function called after a value of a grid changes. Observers are called
+//  in the order they were registered, once the change is made and the grid
+//  holds no locks, so an observer may read the grid and its history, set
+//  values and subscribe and cancel observers.
+//
+//  Changes are delivered one at a time, in the order they were made, so an
+//  observer is never called by two goroutines at once, even when a
+//  ConcurrentGrid writes different regions in parallel. The goroutine
+//  making a change normally calls the observers before its Set method
+//  returns. When a goroutine is already calling observers, as when an
+//  observer sets a value, that goroutine delivers the change instead, after
+//  those made before it.
+type Observer func(Change)
+
+//  A Subscription is the registration of an observer with a grid.
+type Subscription struct {
+	ev       *events
+	match    func(Change) bool
+	fn       Observer
+	canceled int32 // Accessed atomically.
+}
+
+//  Stop calling the subscription's observer, even with changes made before
+//  Cancel that are still waiting to be delivered. Cancel may be called more
+//  than once.
+func (sub *Subscription) Cancel() {
+	var ev = sub.ev
+	atomic.StoreInt32(&sub.canceled, 1)
+	ev.subsMu.Lock()
+	defer ev.subsMu.Unlock()
+	for i, s := range ev.subs {
+		if s == sub {
+			// Copy so that a notification in progress is not disturbed.
+			var subs = make([]*Subscription, 0, len(ev.subs)-1)
+			subs = append(subs, ev.subs[:i]...)
+			ev.subs = append(subs, ev.subs[i+1:]...)
+			atomic.StoreInt32(&ev.observed, int32(len(ev.subs)))
+			return
+		}
+	}
+}
+
+func (h *Grid) subscribe(match func(Change) bool, fn Observer) *Subscription {
+	var (
+		ev  = h.events
+		sub = &Subscription{ev: ev, match: match, fn: fn}
+	)
+	ev.subsMu.Lock()
+	defer ev.subsMu.Unlock()
+	ev.subs = append(ev.subs[:len(ev.subs):len(ev.subs)], sub)
+	atomic.StoreInt32(&ev.observed, int32(len(ev.subs)))
+	return sub
+}
+
+//  A change waiting to be delivered to the observers subscribed when it was
+//  made.
+type notification struct {
+	ch   Change
+	subs []*Subscription
+}
+
+func (n notification) call() {
+	for _, sub := range n.subs {
+		if atomic.LoadInt32(&sub.canceled) != 0 {
+			continue
+		}
+		if sub.match == nil || sub.match(n.ch) {
+			sub.fn(n.ch)
+		}
+	}
+}
+
+//  Queue ch for the current observers of h. The caller holds h.events.mu,
+//  so changes are queued in the order they are recorded.
+func (h *Grid) post(ch Change) {
+	var ev = h.events
+	ev.subsMu.Lock()
+	defer ev.subsMu.Unlock()
+	if len(ev.subs) > 0 {
+		ev.queue = append(ev.queue, notification{ch, ev.subs})
+	}
+}
+
+//  Call observers with the queued changes of h. If another goroutine is
+//  already doing so it also delivers the changes queued by this one, so
+//  deliver returns at once. The caller must not hold any lock of h or of a
+//  ConcurrentGrid guarding it.
+func (h *Grid) deliver() {
+	var ev = h.events
+	ev.subsMu.Lock()
+	if ev.delivering {
+		ev.subsMu.Unlock()
+		return
+	}
+	ev.delivering = true
+	defer func() {
+		ev.delivering = false
+		ev.subsMu.Unlock()
+	}()
+	for len(ev.queue) > 0 {
+		var n = ev.queue[0]
+		ev.queue[0] = notification{}
+		ev.queue = ev.queue[1:]
+		func() {
+			ev.subsMu.Unlock()
+			defer ev.subsMu.Lock()
+			n.call()
+		}()
+	}
+	ev.queue = nil
+}
+
+//  Call fn after every change to a value of h, including changes made by
+//  undoing and redoing history.
+func (h *Grid) Observe(fn Observer) *Subscription {
+	return h.subscribe(nil, fn)
+}
+
+//  Call fn after every change to the value of the tile at c.
+func (h *Grid) ObserveTile(c hexcoords.Hex, fn Observer) *Subscription {
+	c = h.wrap(c)
+	return h.subscribe(func(ch Change) bool {
+		return ch.Kind == TileChange && ch.Hex == c
+	}, fn)
+}
+
+//  Call fn after every change to the value of vertex vc.
+func (h *Grid) ObserveVertex(vc hexcoords.Vertex, fn Observer) *Subscription {
+	var canon = h.canonicalVertex(vc)
+	return h.subscribe(func(ch Change) bool {
+		return ch.Kind == VertexChange && ch.Vertex == canon
+	}, fn)
+}
+
+//  Call fn after every change to the value of edge e.
+func (h *Grid) ObserveEdge(e hexcoords.Edge, fn Observer) *Subscription {
+	var canon = h.canonicalEdge(e)
+	return h.subscribe(func(ch Change) bool {
+		return ch.Kind == EdgeChange && ch.Edge == canon
+	}, fn)
+}
+
+//  Call fn after every change to a tile with coordinates between min and
+//  max (inclusive), or to a vertex or edge of such a tile.
+func (h *Grid) ObserveRegion(min, max hexcoords.Hex, fn Observer) *Subscription {
+	var inRegion = func(c hexcoords.Hex) bool {
+		return min.U <= c.U && c.U <= max.U && min.V <= c.V && c.V <= max.V
+	}
+	return h.subscribe(func(ch Change) bool {
+		for _, c := range ch.Incidents() {
+			if c, ok := h.Wrap(c); ok && inRegion(c) {
+				return true
+			}
+		}
+		return false
+	}, fn)
+}
+
+//  Send every change to a value of h on c. Sends do not block; if c is not
+//  ready to receive a change is dropped, so the caller should give c
+//  sufficient buffer space for the rate of changes expected.
+func (h *Grid) Notify(c chan<- Change) *Subscription {
+	return h.subscribe(nil, func(ch Change) {
+		select {
+		case c <- ch:
+		default:
+		}
+	})
+}
+
+//  The tiles whose tile, vertex or edge is modified by ch. The result may
+//  include coordinates outside the grid.
+func (ch Change) Incidents() []hexcoords.Hex {
+	switch ch.Kind {
+	case TileChange:
+		return []hexcoords.Hex{ch.Hex}
+	case VertexChange:
+		return ch.Vertex.Incidents()
+	case EdgeChange:
+		return ch.Edge.Incidents()
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+File: observe_test.go
+Created: Mon Oct 19 00:12:37 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestObserve(T *testing.T) {
+	var (
+		h         = NewGrid(7, 7, 1, 0, 0, 0)
+		c         = hexcoords.Hex{0, 0}
+		vc        = hexcoords.Vertex{0, 0, 3}
+		ec        = c.Edges(hex.N)[0]
+		all       int
+		tile      int
+		vertex    int
+		edge      int
+		region    int
+		elsewhere int
+	)
+	var sub = h.Observe(func(Change) { all++ })
+	h.ObserveTile(c, func(Change) { tile++ })
+	h.ObserveVertex(vc.IdenticalVertices()[0], func(Change) { vertex++ })
+	h.ObserveEdge(c.Adjacent(hex.N).Edges(hex.S)[0], func(Change) { edge++ })
+	h.ObserveRegion(hexcoords.Hex{-1, -1}, hexcoords.Hex{1, 1}, func(Change) { region++ })
+	h.ObserveRegion(hexcoords.Hex{3, 3}, hexcoords.Hex{3, 3}, func(Change) { elsewhere++ })
+
+	h.SetTileValue(c, 1)
+	h.SetVertexValue(vc, 2)
+	h.SetEdgeValue(ec, 3)
+	if all != 3 || tile != 1 || vertex != 1 || edge != 1 || region != 3 || elsewhere != 0 {
+		T.Errorf("counts all=%d tile=%d vertex=%d edge=%d region=%d elsewhere=%d",
+			all, tile, vertex, edge, region, elsewhere)
+	}
+
+	h.StartHistory()
+	h.SetTileValue(c, 4)
+	h.History().Undo()
+	if tile != 3 {
+		T.Errorf("undo not observed")
+	}
+
+	sub.Cancel()
+	sub.Cancel()
+	h.SetTileValue(c, 5)
+	if all != 5 {
+		T.Errorf("canceled observer called")
+	}
+}
+
+//  A value set by an observer is delivered to every observer after the
+//  change that caused it, and observers may read the history meanwhile.
+func TestObserveNested(T *testing.T) {
+	var (
+		h          = NewGrid(7, 7, 1, 0, 0, 0)
+		hist       = h.StartHistory()
+		a, b       = hexcoords.Hex{0, 0}, hexcoords.Hex{1, 1}
+		first, all []hexcoords.Hex
+	)
+	h.ObserveTile(a, func(ch Change) {
+		first = append(first, ch.Hex)
+		h.SetTileValue(b, h.TileValue(a))
+		if n := h.History().Len(); n != 2 {
+			T.Errorf("%d changes recorded inside an observer", n)
+		}
+	})
+	h.Observe(func(ch Change) { all = append(all, ch.Hex) })
+	h.SetTileValue(a, 1)
+	if len(first) != 1 || len(all) != 2 || all[0] != a || all[1] != b {
+		T.Errorf("changes observed %v and %v", first, all)
+	}
+	if h.TileValue(b) != 1 || hist.Len() != 2 {
+		T.Errorf("value %v set by observer, %d changes", h.TileValue(b), hist.Len())
+	}
+}
+
+func TestNotify(T *testing.T) {
+	var (
+		h  = NewCompactGrid(5, 5, 1, 0, 0, 0)
+		c  = hexcoords.Hex{1, 1}
+		ch = make(chan Change, 1)
+	)
+	h.Notify(ch)
+	h.SetTileValue(c, 1)
+	h.SetTileValue(c, 2) // Dropped; the channel is full.
+	var change = <-ch
+	if change.Kind != TileChange || change.Hex != c || change.Old != 0 || change.New != 1 {
+		T.Errorf("unexpected change %#v", change)
+	}
+	select {
+	case change = <-ch:
+		T.Errorf("unexpected change %#v", change)
+	default:
+	}
+}
+
+func TestChangeIncidents(T *testing.T) {
+	for k := 0; k < 6; k++ {
+		var (
+			vc    = hexcoords.Vertex{1, 0, k}
+			tiles = Change{Kind: VertexChange, Vertex: vc}.Incidents()
+			seen  = make(map[hexcoords.Hex]bool)
+		)
+		for _, c := range tiles {
+			seen[c] = true
+		}
+		if len(tiles) != 3 || len(seen) != 3 || !seen[vc.Hex()] {
+			T.Errorf("vertex %v has incident tiles %v", vc, tiles)
+		}
+	}
+	var e = (hexcoords.Hex{0, 0}).Edges(hex.NE)[0]
+	if tiles := (Change{Kind: EdgeChange, Edge: e}).Incidents(); len(tiles) != 2 || tiles[0] == tiles[1] {
+		T.Errorf("edge %v has incident tiles %v", e, tiles)
+	}
+	if tiles := (Change{Kind: TileChange, Hex: hexcoords.Hex{2, 1}}).Incidents(); len(tiles) != 1 {
+		T.Errorf("tile change has incident tiles %v", tiles)
+	}
+}
+
+func TestObserveRegionSeam(T *testing.T) {
+	var (
+		h       = NewWrappedGrid(8, 5, 1, WrapHorizontal, 0, 0, 0)
+		c       = hexcoords.Hex{h.ColMax(), 0}
+		west    int
+		changes int
+	)
+	h.ObserveRegion(hexcoords.Hex{h.ColMin(), h.RowMin()}, hexcoords.Hex{h.ColMin(), h.RowMax()}, func(Change) { west++ })
+	h.Observe(func(Change) { changes++ })
+	h.SetVertexValue(hexcoords.Vertex{c.U, c.V, 1}, 1)
+	h.SetEdgeValue(c.Edges(hex.SE)[0], 1)
+	h.SetTileValue(c, 1)
+	if changes != 3 || west != 2 {
+		T.Errorf("%d of %d changes across the seam observed, expected 2", west, changes)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+File: outline.go
+Created: Mon Oct 19 01:05:51 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+)
+
+//  A closed chain of tile sides. Each edge is given by the coordinates of
+//  the side of the region tile it belongs to, so the region lies to the
+//  left of the edge running from corner K to corner L. Points[i] is the
+//  position of the start of Edges[i]; the polygon closes from the last
+//  point back to the first.
+type OutlineLoop struct {
+	Edges  []hexcoords.Edge
+	Points []point.Point
+}
+
+//  The signed area of the polygon of loop. Positive for a counter-clockwise
+//  loop.
+func (loop OutlineLoop) signedArea() float64 {
+	var sum float64
+	for i, p := range loop.Points {
+		sum += p.Cross(loop.Points[(i+1)%len(loop.Points)])
+	}
+	return sum / 2
+}
+
+//  The outline of a group of connected tiles. The outer boundary runs
+//  counter-clockwise. Each hole (an area surrounded by the group but not
+//  part of it) is bounded by a clockwise loop.
+type Outline struct {
+	Outer OutlineLoop
+	Holes []OutlineLoop
+}
+
+//  The outlines of the tiles of region within the bounds of h, one for each
+//  group of adjacent tiles in the order the groups' first tiles appear in
+//  region. Shared sides of adjacent tiles are merged away. On a wrapping
+//  grid the edges of a group crossing a wrapping border are chained
+//  correctly, but its points jump between opposite sides of the grid and do
+//  not form a meaningful polygon.
+func (h *Grid) Outlines(region []hexcoords.Hex) []Outline {
+	var (
+		in    = h.NewHexSet()
+		tiles []hexcoords.Hex
+	)
+	for _, c := range region {
+		if c, ok := h.Wrap(c); ok && in.Add(c) {
+			tiles = append(tiles, c)
+		}
+	}
+
+	// Assign each tile to a group.
+	var (
+		group   = make(map[hexcoords.Hex]int, len(tiles))
+		ngroups int
+		same    = func(a, b *Tile) bool { return in.Contains(b.Hex) }
+	)
+	for _, c := range tiles {
+		if _, ok := group[c]; ok {
+			continue
+		}
+		for _, member := range h.FloodFill(c, same) {
+			group[member] = ngroups
+		}
+		ngroups++
+	}
+
+	// Boundary sides directed counter-clockwise around their tile, indexed
+	// by their starting vertex. In a hexagonal tiling every boundary vertex
+	// starts exactly one boundary side.
+	var (
+		sides   []hexcoords.Edge
+		byStart = make(map[hexcoords.Vertex]int)
+	)
+	for _, c := range tiles {
+		for k := 0; k < 6; k++ {
+			if adj, ok := h.Wrap(c.Adjacent(hex.Edge(k).Direction())); ok && in.Contains(adj) {
+				continue
+			}
+			byStart[h.canonicalVertex(hexcoords.Vertex{c.U, c.V, k})] = len(sides)
+			sides = append(sides, hexcoords.Edge{c.U, c.V, k, hex.VertexIndexCounterClockwise(k)})
+		}
+	}
+
+	var (
+		outlines = make([]Outline, ngroups)
+		used     = make([]bool, len(sides))
+	)
+	for i := range sides {
+		if used[i] {
+			continue
+		}
+		var loop OutlineLoop
+		for j := i; !used[j]; {
+			var (
+				e      = sides[j]
+				points = h.GetHex(e.Hex())
+			)
+			used[j] = true
+			loop.Edges = append(loop.Edges, e)
+			loop.Points = append(loop.Points, points.Point(e.K))
+			j = byStart[h.canonicalVertex(hexcoords.Vertex{e.U, e.V, e.L})]
+		}
+		var g = group[sides[i].Hex()]
+		if loop.signedArea() > 0 {
+			outlines[g].Outer = loop
+		} else {
+			outlines[g].Holes = append(outlines[g].Holes, loop)
+		}
+	}
+	return outlines
+}
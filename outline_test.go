@@ -0,0 +1,74 @@
+/*
+File: outline_test.go
+Created: Mon Oct 19 01:05:51 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"math"
+	"testing"
+)
+
+func TestOutlinesSingleTile(T *testing.T) {
+	var (
+		h        = NewGrid(5, 5, 1, nil, nil, nil)
+		c        = hexcoords.Hex{0, 0}
+		outlines = h.Outlines([]hexcoords.Hex{c, c})
+	)
+	if len(outlines) != 1 {
+		T.Fatalf("%d outlines", len(outlines))
+	}
+	var outer = outlines[0].Outer
+	if len(outer.Edges) != 6 || len(outlines[0].Holes) != 0 {
+		T.Errorf("%d edges, %d holes", len(outer.Edges), len(outlines[0].Holes))
+	}
+	if math.Abs(outer.signedArea()-h.GetHex(c).Area()) > 1e-9 {
+		T.Errorf("outline area %v, hexagon area %v", outer.signedArea(), h.GetHex(c).Area())
+	}
+}
+
+func TestOutlinesRing(T *testing.T) {
+	var (
+		h      = NewGrid(7, 7, 1, nil, nil, nil)
+		center = hexcoords.Hex{0, 0}
+		ring   []hexcoords.Hex
+	)
+	for _, dir := range hex.EdgeDirections() {
+		ring = append(ring, center.Adjacent(dir))
+	}
+	ring = append(ring, hexcoords.Hex{3, 3})
+	var outlines = h.Outlines(ring)
+	if len(outlines) != 2 {
+		T.Fatalf("%d outlines", len(outlines))
+	}
+	var ringOutline = outlines[0]
+	if len(ringOutline.Outer.Edges) != 18 {
+		T.Errorf("%d outer edges", len(ringOutline.Outer.Edges))
+	}
+	if len(ringOutline.Holes) != 1 || len(ringOutline.Holes[0].Edges) != 6 {
+		T.Fatalf("holes %v", ringOutline.Holes)
+	}
+	var hole = ringOutline.Holes[0]
+	if math.Abs(hole.signedArea()+h.GetHex(center).Area()) > 1e-9 {
+		T.Errorf("hole area %v", hole.signedArea())
+	}
+	for i, e := range hole.Edges {
+		var ident bool
+		for _, ce := range center.Edges(hex.NilDirection) {
+			ident = ident || e.IsIdentical(ce)
+		}
+		if !ident {
+			T.Errorf("hole edge %v is not a side of the center tile", e)
+		}
+		if !hole.Points[i].ApproxEqual(h.GetVertexPoint(hexcoords.Vertex{e.U, e.V, e.K})) {
+			T.Errorf("hole point %d does not start edge %v", i, e)
+		}
+	}
+	if len(outlines[1].Outer.Edges) != 6 {
+		T.Errorf("isolated tile has %d edges", len(outlines[1].Outer.Edges))
+	}
+}
@@ -0,0 +1,137 @@
+/*
+File: path.go
+Created: Mon Oct 19 02:41:56 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"container/heap"
+	"math"
+)
+
+//  The cost of moving between adjacent tiles, from the tile at from to the
+//  tile at to. A negative or infinite cost means the move is impossible.
+//  A nil StepCost makes every move cost 1.
+type StepCost func(from, to hexcoords.Hex) float64
+
+func (cost StepCost) between(from, to hexcoords.Hex) float64 {
+	if cost == nil {
+		return 1
+	}
+	return cost(from, to)
+}
+
+//  A StepCost charging cost(v) for entering a tile of h holding value v.
+func (h *Grid) TileCost(cost func(v Value) float64) StepCost {
+	return func(from, to hexcoords.Hex) float64 {
+		return cost(h.TileValue(to))
+	}
+}
+
+func passable(cost float64) bool {
+	return cost >= 0 && !math.IsInf(cost, 1)
+}
+
+//  A priority queue of tile slots.
+type slotQueue struct {
+	slots    []int
+	priority []float64
+}
+
+func (q *slotQueue) Len() int           { return len(q.slots) }
+func (q *slotQueue) Less(i, j int) bool { return q.priority[i] < q.priority[j] }
+func (q *slotQueue) Swap(i, j int) {
+	q.slots[i], q.slots[j] = q.slots[j], q.slots[i]
+	q.priority[i], q.priority[j] = q.priority[j], q.priority[i]
+}
+func (q *slotQueue) Push(x interface{}) {
+	var item = x.(slotItem)
+	q.slots = append(q.slots, item.slot)
+	q.priority = append(q.priority, item.priority)
+}
+func (q *slotQueue) Pop() interface{} {
+	var (
+		n    = len(q.slots) - 1
+		item = slotItem{q.slots[n], q.priority[n]}
+	)
+	q.slots = q.slots[:n]
+	q.priority = q.priority[:n]
+	return item
+}
+
+type slotItem struct {
+	slot     int
+	priority float64
+}
+
+//  The coordinates of the tile in slot.
+func (h *Grid) slotHex(slot int) hexcoords.Hex {
+	return h.hexCoords(slot/h.m, slot%h.m)
+}
+
+//  Find the cheapest sequence of adjacent tiles leading from the tile at
+//  from to the tile at to with the A* algorithm, taking wrapping borders
+//  into account. The path includes both ends. The second return value is
+//  the total cost of the path and the third is false if to cannot be
+//  reached.
+//
+//  The search is guided by Distance, which assumes every move costs at
+//  least 1. With cheaper moves the path found may not be the cheapest.
+func (h *Grid) ShortestPath(from, to hexcoords.Hex, cost StepCost) ([]hexcoords.Hex, float64, bool) {
+	from, okFrom := h.Wrap(from)
+	to, okTo := h.Wrap(to)
+	if !okFrom || !okTo {
+		return nil, 0, false
+	}
+	var (
+		start  = h.tileSlot(from)
+		goal   = h.tileSlot(to)
+		dist   = map[int]float64{start: 0}
+		parent = map[int]int{start: -1}
+		closed = make(map[int]bool)
+		open   = new(slotQueue)
+	)
+	heap.Push(open, slotItem{start, float64(h.Distance(from, to))})
+	for open.Len() > 0 {
+		var slot = heap.Pop(open).(slotItem).slot
+		if slot == goal {
+			break
+		}
+		if closed[slot] {
+			continue
+		}
+		closed[slot] = true
+		var c = h.slotHex(slot)
+		h.eachAdjacent(c, func(adj hexcoords.Hex) {
+			var step = cost.between(c, adj)
+			if !passable(step) {
+				return
+			}
+			var (
+				adjSlot = h.tileSlot(adj)
+				d       = dist[slot] + step
+			)
+			if old, ok := dist[adjSlot]; ok && old <= d {
+				return
+			}
+			dist[adjSlot] = d
+			parent[adjSlot] = slot
+			heap.Push(open, slotItem{adjSlot, d + float64(h.Distance(adj, to))})
+		})
+	}
+	var total, ok = dist[goal]
+	if !ok {
+		return nil, 0, false
+	}
+	var path []hexcoords.Hex
+	for slot := goal; slot >= 0; slot = parent[slot] {
+		path = append(path, h.slotHex(slot))
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, total, true
+}
@@ -0,0 +1,217 @@
+/*
+File: persistent.go
+Created: Sun Oct 18 23:45:12 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+)
+
+//  Returns a copy of h whose values can be modified without affecting h,
+//  and vice versa. Values themselves are copied shallowly; a pointer value
+//  refers to the same object in both grids. Immutable geometry (points,
+//  hexagons and slot indices) is shared. The copy does not record history
+//  and has no observers.
+func (h *Grid) Clone() *Grid {
+	var cp = new(Grid)
+	*cp = *h
+	cp.events = new(events)
+	if h.compact != nil {
+		cp.compact = &compactStorage{
+			tiles:    append([]Value(nil), h.compact.tiles...),
+			vertices: append([]Value(nil), h.compact.vertices...),
+			edges:    append([]Value(nil), h.compact.edges...),
+		}
+		return cp
+	}
+	cp.t = append([]Tile(nil), h.t...)
+	cp.v = append([]Vertex(nil), h.v...)
+	cp.e = append([]Edge(nil), h.e...)
+	return cp
+}
+
+//  A PersistentGrid is an immutable version of a grid's values. Setting a
+//  value returns a new version and leaves the original untouched. Versions
+//  share all storage not affected by their differences, so deriving a new
+//  version costs time and memory logarithmic in the size of the grid. This
+//  makes it cheap to explore many hypothetical moves, as in game tree
+//  search.
+//
+//  A PersistentGrid is safe for concurrent use by multiple goroutines.
+type PersistentGrid struct {
+	geom     *Grid // Dimensions only; holds no values.
+	tiles    *pvector
+	vertices *pvector
+	edges    *pvector
+}
+
+//  Create the first version of a persistent grid holding the current
+//  values of h.
+func (h *Grid) Persistent() *PersistentGrid {
+	var (
+		s  = h.compactCopy().compact
+		pg = &PersistentGrid{geom: newGridTopology(h.n, h.m, h.radius, h.topology)}
+	)
+	pg.tiles = newPVector(s.tiles)
+	pg.vertices = newPVector(s.vertices)
+	pg.edges = newPVector(s.edges)
+	return pg
+}
+
+//  A compact grid holding the values of pg.
+func (pg *PersistentGrid) Thaw() *Grid {
+	var h = newGridTopology(pg.geom.n, pg.geom.m, pg.geom.radius, pg.geom.topology)
+	h.compact = &compactStorage{
+		tiles:    pg.tiles.values(),
+		vertices: pg.vertices.values(),
+		edges:    pg.edges.values(),
+	}
+	return h
+}
+
+//  See Grid.TileValue.
+func (pg *PersistentGrid) TileValue(c hexcoords.Hex) Value {
+	c, ok := pg.geom.Wrap(c)
+	if !ok {
+		return nil
+	}
+	return pg.tiles.get(pg.geom.tileSlot(c))
+}
+
+//  Returns a new version of pg in which the tile at c has the given value.
+//  Panics if c is not within the bounds of the grid.
+func (pg *PersistentGrid) SetTileValue(c hexcoords.Hex, value Value) *PersistentGrid {
+	c, ok := pg.geom.Wrap(c)
+	if !ok {
+		panic("outofbounds")
+	}
+	var next = *pg
+	next.tiles = pg.tiles.set(pg.geom.tileSlot(c), value)
+	return &next
+}
+
+//  See Grid.VertexValue.
+func (pg *PersistentGrid) VertexValue(vc hexcoords.Vertex) Value {
+	var slot, ok = pg.geom.vertexSlot(vc)
+	if !ok {
+		return nil
+	}
+	return pg.vertices.get(slot)
+}
+
+//  Returns a new version of pg in which vertex vc has the given value.
+//  Panics if vc is not a vertex of the grid.
+func (pg *PersistentGrid) SetVertexValue(vc hexcoords.Vertex, value Value) *PersistentGrid {
+	var slot, ok = pg.geom.vertexSlot(vc)
+	if !ok {
+		panic("outofbounds")
+	}
+	var next = *pg
+	next.vertices = pg.vertices.set(slot, value)
+	return &next
+}
+
+//  See Grid.EdgeValue.
+func (pg *PersistentGrid) EdgeValue(e hexcoords.Edge) Value {
+	var slot, ok = pg.geom.edgeSlot(e)
+	if !ok {
+		return nil
+	}
+	return pg.edges.get(slot)
+}
+
+//  Returns a new version of pg in which edge e has the given value. Panics
+//  if e is not an edge of the grid.
+func (pg *PersistentGrid) SetEdgeValue(e hexcoords.Edge, value Value) *PersistentGrid {
+	var slot, ok = pg.geom.edgeSlot(e)
+	if !ok {
+		panic("outofbounds")
+	}
+	var next = *pg
+	next.edges = pg.edges.set(slot, value)
+	return &next
+}
+
+/*
+A persistent vector is a trie with pvectorWidth children per node. Values
+live in the leaves. Setting an element copies the nodes on the path from the
+root to its leaf and shares every other node with the original.
+*/
+
+const (
+	pvectorBits  = 5
+	pvectorWidth = 1 << pvectorBits
+	pvectorMask  = pvectorWidth - 1
+)
+
+type pnode struct {
+	children []*pnode
+	values   []Value
+}
+
+type pvector struct {
+	root  *pnode
+	shift uint // Bits of the index consumed below the root.
+	size  int
+}
+
+func newPVector(values []Value) *pvector {
+	var (
+		level []*pnode
+		shift uint
+	)
+	for i := 0; i < len(values) || i == 0; i += pvectorWidth {
+		var leaf = &pnode{values: make([]Value, pvectorWidth)}
+		copy(leaf.values, values[i:imin(i+pvectorWidth, len(values))])
+		level = append(level, leaf)
+	}
+	for len(level) > 1 {
+		var parents []*pnode
+		for i := 0; i < len(level); i += pvectorWidth {
+			var node = &pnode{children: make([]*pnode, pvectorWidth)}
+			copy(node.children, level[i:imin(i+pvectorWidth, len(level))])
+			parents = append(parents, node)
+		}
+		level = parents
+		shift += pvectorBits
+	}
+	return &pvector{root: level[0], shift: shift, size: len(values)}
+}
+
+func (v *pvector) get(i int) Value {
+	var node = v.root
+	for shift := v.shift; shift > 0; shift -= pvectorBits {
+		node = node.children[(i>>shift)&pvectorMask]
+	}
+	return node.values[i&pvectorMask]
+}
+
+func (v *pvector) set(i int, x Value) *pvector {
+	var (
+		next = &pvector{shift: v.shift, size: v.size}
+		src  = v.root
+		dst  = new(pnode)
+	)
+	next.root = dst
+	for shift := v.shift; shift > 0; shift -= pvectorBits {
+		var k = (i >> shift) & pvectorMask
+		dst.children = append([]*pnode(nil), src.children...)
+		src = src.children[k]
+		dst.children[k] = new(pnode)
+		dst = dst.children[k]
+	}
+	dst.values = append([]Value(nil), src.values...)
+	dst.values[i&pvectorMask] = x
+	return next
+}
+
+//  All elements of v in a new slice.
+func (v *pvector) values() []Value {
+	var values = make([]Value, v.size)
+	for i := range values {
+		values[i] = v.get(i)
+	}
+	return values
+}
@@ -0,0 +1,87 @@
+/*
+File: persistent_test.go
+Created: Sun Oct 18 23:45:12 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestClone(T *testing.T) {
+	for _, h := range []*Grid{NewGrid(5, 5, 1, 0, 0, 0), NewCompactGrid(5, 5, 1, 0, 0, 0)} {
+		var (
+			cp = h.Clone()
+			c  = hexcoords.Hex{1, -1}
+			vc = hexcoords.Vertex{1, -1, 2}
+			e  = c.Edges(hex.SE)[0]
+		)
+		cp.SetTileValue(c, 1)
+		cp.SetVertexValue(vc, 2)
+		cp.SetEdgeValue(e, 3)
+		if h.TileValue(c) != 0 || h.VertexValue(vc) != 0 || h.EdgeValue(e) != 0 {
+			T.Errorf("compact=%v: modifying the clone changed the original", h.IsCompact())
+		}
+		if cp.TileValue(c) != 1 || cp.VertexValue(vc) != 2 || cp.EdgeValue(e) != 3 {
+			T.Errorf("compact=%v: clone was not modified", h.IsCompact())
+		}
+		// Objects retrieved from the clone must belong to the clone.
+		if !h.IsCompact() && cp.GetTile(c) == h.GetTile(c) {
+			T.Errorf("clone aliases the tiles of the original")
+		}
+	}
+}
+
+func TestPersistentVersions(T *testing.T) {
+	var (
+		h  = NewGrid(33, 33, 1, 0, 0, 0)
+		v0 = h.Persistent()
+		c  = hexcoords.Hex{5, -7}
+		vc = hexcoords.Vertex{5, -7, 4}
+		e  = c.Edges(hex.NW)[0]
+		v1 = v0.SetTileValue(c, "a")
+		v2 = v1.SetVertexValue(vc, "b").SetEdgeValue(e, "c")
+		v3 = v1.SetTileValue(c, "d")
+	)
+	if v0.TileValue(c) != 0 || v1.TileValue(c) != "a" || v2.TileValue(c) != "a" || v3.TileValue(c) != "d" {
+		T.Errorf("tile versions: %v %v %v %v", v0.TileValue(c), v1.TileValue(c), v2.TileValue(c), v3.TileValue(c))
+	}
+	if v1.VertexValue(vc) != 0 || v2.VertexValue(vc.IdenticalVertices()[1]) != "b" {
+		T.Errorf("vertex versions: %v %v", v1.VertexValue(vc), v2.VertexValue(vc))
+	}
+	if v3.EdgeValue(e) != 0 || v2.EdgeValue(c.Adjacent(hex.NW).Edges(hex.SE)[0]) != "c" {
+		T.Errorf("edge versions: %v %v", v3.EdgeValue(e), v2.EdgeValue(e))
+	}
+	if h.TileValue(c) != 0 {
+		T.Errorf("persistent versions modified the source grid")
+	}
+	var thawed = v2.Thaw()
+	if thawed.TileValue(c) != "a" || thawed.EdgeValue(e) != "c" || thawed.TileValue(hexcoords.Hex{0, 0}) != 0 {
+		T.Errorf("thawed grid does not match its version")
+	}
+}
+
+func TestPVector(T *testing.T) {
+	for _, n := range []int{0, 1, 31, 32, 33, 1025, 40000} {
+		var values = make([]Value, n)
+		for i := range values {
+			values[i] = i
+		}
+		var v = newPVector(values)
+		for i := 0; i < n; i += 7 {
+			var w = v.set(i, -i)
+			if w.get(i) != -i || v.get(i) != i {
+				T.Fatalf("n=%d: set %d did not produce a new version", n, i)
+			}
+		}
+		for i, x := range v.values() {
+			if x != i {
+				T.Fatalf("n=%d: element %d is %v", n, i, x)
+			}
+		}
+	}
+}
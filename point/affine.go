@@ -0,0 +1,156 @@
+/*
+File: affine.go
+Created: Sun Oct 18 22:01:09 UTC 2026
+*/
+
+package point
+
+import (
+	"math"
+)
+
+//  An affine transformation of the plane. An Affine holds the top two rows
+//  of a 3x3 homogeneous matrix; the bottom row is implicitly (0, 0, 1).
+//
+//	| a[0][0] a[0][1] a[0][2] |   | x |
+//	| a[1][0] a[1][1] a[1][2] | * | y |
+//	|    0       0       1    |   | 1 |
+//
+//  The zero value is not the identity transform. See Identity.
+type Affine [2][3]float64
+
+//  The transform that maps every point to itself.
+func Identity() Affine {
+	return Affine{
+		{1, 0, 0},
+		{0, 1, 0},
+	}
+}
+
+//  A transform moving every point by the vector p.
+func Translation(p Point) Affine {
+	return Affine{
+		{1, 0, p.X},
+		{0, 1, p.Y},
+	}
+}
+
+//  A transform scaling the X and Y axes by sx and sy about the origin.
+func Scaling(sx, sy float64) Affine {
+	return Affine{
+		{sx, 0, 0},
+		{0, sy, 0},
+	}
+}
+
+//  A counter-clockwise rotation by theta radians about the origin. Applying
+//  Rotation(theta) to p is equivalent to p.Rot(theta).
+func Rotation(theta float64) Affine {
+	var (
+		cos = math.Cos(theta)
+		sin = math.Sin(theta)
+	)
+	return Affine{
+		{cos, -sin, 0},
+		{sin, cos, 0},
+	}
+}
+
+//  A counter-clockwise rotation by theta radians about center. Applying
+//  RotationAround(theta, center) to p is equivalent to
+//  p.RotAround(theta, center).
+func RotationAround(theta float64, center Point) Affine {
+	return Translation(center).
+		Compose(Rotation(theta)).
+		Compose(Translation(center.Scale(-1)))
+}
+
+//  A shear transform mapping (x, y) to (x + kx*y, y + ky*x).
+func Shear(kx, ky float64) Affine {
+	return Affine{
+		{1, kx, 0},
+		{ky, 1, 0},
+	}
+}
+
+//  Returns the transform equivalent to applying b and then a (the matrix
+//  product a*b). Chains read right to left, like function composition.
+func (a Affine) Compose(b Affine) Affine {
+	return Affine{
+		{
+			a[0][0]*b[0][0] + a[0][1]*b[1][0],
+			a[0][0]*b[0][1] + a[0][1]*b[1][1],
+			a[0][0]*b[0][2] + a[0][1]*b[1][2] + a[0][2],
+		},
+		{
+			a[1][0]*b[0][0] + a[1][1]*b[1][0],
+			a[1][0]*b[0][1] + a[1][1]*b[1][1],
+			a[1][0]*b[0][2] + a[1][1]*b[1][2] + a[1][2],
+		},
+	}
+}
+
+//  The determinant of the linear part of a. A transform with a zero
+//  determinant collapses the plane and can not be inverted.
+func (a Affine) Det() float64 {
+	return a[0][0]*a[1][1] - a[0][1]*a[1][0]
+}
+
+//  Returns the inverse transform of a. The second return value is false
+//  when a is singular, in which case the returned Affine is the zero value.
+func (a Affine) Invert() (Affine, bool) {
+	var det = a.Det()
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return Affine{}, false
+	}
+	var (
+		i00 = a[1][1] / det
+		i01 = -a[0][1] / det
+		i10 = -a[1][0] / det
+		i11 = a[0][0] / det
+	)
+	return Affine{
+		{i00, i01, -(i00*a[0][2] + i01*a[1][2])},
+		{i10, i11, -(i10*a[0][2] + i11*a[1][2])},
+	}, true
+}
+
+//  Transform the point p.
+func (a Affine) Apply(p Point) Point {
+	return Point{
+		a[0][0]*p.X + a[0][1]*p.Y + a[0][2],
+		a[1][0]*p.X + a[1][1]*p.Y + a[1][2],
+	}
+}
+
+//  Transform p as a displacement rather than a position; the translation
+//  part of a is ignored.
+func (a Affine) ApplyVector(p Point) Point {
+	return Point{
+		a[0][0]*p.X + a[0][1]*p.Y,
+		a[1][0]*p.X + a[1][1]*p.Y,
+	}
+}
+
+//  Transform a slice of points. A new slice is returned; points is not
+//  modified.
+func (a Affine) ApplyAll(points []Point) []Point {
+	var transformed = make([]Point, len(points))
+	for i, p := range points {
+		transformed[i] = a.Apply(p)
+	}
+	return transformed
+}
+
+//  Returns true if every entry of a is within PointApproximationGap of the
+//  corresponding entry of b.
+func (a Affine) ApproxEqual(b Affine) bool {
+	for i := range a {
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) >= PointApproximationGap {
+				return false
+			}
+		}
+	}
+	return true
+}
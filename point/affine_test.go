@@ -0,0 +1,72 @@
+/*
+File: affine_test.go
+Created: Sun Oct 18 22:01:09 UTC 2026
+*/
+
+package point
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAffineIdentity(T *testing.T) {
+	var p = Point{3.5, -2}
+	if !Identity().Apply(p).ApproxEqual(p) {
+		T.Errorf("identity moved %v to %v", p, Identity().Apply(p))
+	}
+	if !Identity().Compose(Translation(p)).ApproxEqual(Translation(p)) {
+		T.Errorf("identity composition changed the transform")
+	}
+}
+
+func TestAffineMatchesPointMethods(T *testing.T) {
+	var (
+		p      = Point{43, -15}
+		center = Point{10, 10}
+	)
+	if q := Rotation(math.Pi / 3).Apply(p); !q.ApproxEqual(p.Rot(math.Pi / 3)) {
+		T.Errorf("Rotation %v != Rot %v", q, p.Rot(math.Pi/3))
+	}
+	if q := RotationAround(1, center).Apply(p); q.Sub(p.RotAround(1, center)).Norm() > e {
+		T.Errorf("RotationAround %v != RotAround %v", q, p.RotAround(1, center))
+	}
+	if q := Translation(center).Apply(p); !q.ApproxEqual(p.Add(center)) {
+		T.Errorf("Translation %v != Add %v", q, p.Add(center))
+	}
+	if q := Scaling(2, 2).Apply(p); !q.ApproxEqual(p.Scale(2)) {
+		T.Errorf("Scaling %v != Scale %v", q, p.Scale(2))
+	}
+}
+
+func TestAffineCompose(T *testing.T) {
+	var (
+		p = Point{1, 2}
+		a = Translation(Point{5, 0}).Compose(Scaling(2, 3))
+		q = a.Apply(p)
+	)
+	// Scale first, then translate.
+	if !q.ApproxEqual(Point{7, 6}) {
+		T.Errorf("composition order is wrong: %v", q)
+	}
+	if v := a.ApplyVector(p); !v.ApproxEqual(Point{2, 6}) {
+		T.Errorf("ApplyVector translated its argument: %v", v)
+	}
+}
+
+func TestAffineInvert(T *testing.T) {
+	var (
+		p = Point{-7, 11}
+		a = Translation(Point{5, -1}).Compose(Rotation(0.7)).Compose(Shear(0.5, 0))
+	)
+	inv, ok := a.Invert()
+	if !ok {
+		T.Fatalf("invertible transform reported singular")
+	}
+	if q := inv.Apply(a.Apply(p)); q.Sub(p).Norm() > e {
+		T.Errorf("inverse did not undo transform: %v != %v", q, p)
+	}
+	if _, ok := Scaling(1, 0).Invert(); ok {
+		T.Errorf("singular transform reported invertible")
+	}
+}
@@ -0,0 +1,213 @@
+/*
+File: geometry.go
+Created: Sun Oct 18 22:14:32 UTC 2026
+*/
+
+package point
+
+import (
+	"math"
+)
+
+//  The z component of the 3-dimensional cross product of p and p2. The
+//  result is positive when p2 is counter-clockwise of p.
+func (p Point) Cross(p2 Point) float64 {
+	return p.X*p2.Y - p.Y*p2.X
+}
+
+//  An axis-aligned rectangle containing the points with Min.X <= X <= Max.X
+//  and Min.Y <= Y <= Max.Y. A Rect with Min greater than Max in either
+//  component is empty.
+type Rect struct{ Min, Max Point }
+
+//  A rectangle containing no points. The union of an empty rectangle and r
+//  is r.
+func EmptyRect() Rect {
+	var inf = math.Inf(1)
+	return Rect{Point{inf, inf}, Point{-inf, -inf}}
+}
+
+//  The smallest rectangle containing each of points. Returns EmptyRect()
+//  if no points are given.
+func Bounds(points ...Point) Rect {
+	var r = EmptyRect()
+	for _, p := range points {
+		r = r.Add(p)
+	}
+	return r
+}
+
+func (r Rect) Empty() bool {
+	return r.Min.X > r.Max.X || r.Min.Y > r.Max.Y
+}
+func (r Rect) Width() float64 {
+	if r.Empty() {
+		return 0
+	}
+	return r.Max.X - r.Min.X
+}
+func (r Rect) Height() float64 {
+	if r.Empty() {
+		return 0
+	}
+	return r.Max.Y - r.Min.Y
+}
+func (r Rect) Center() Point {
+	return r.Min.Add(r.Max).Scale(0.5)
+}
+
+//  Returns true if p lies inside r or on its boundary.
+func (r Rect) Contains(p Point) bool {
+	return r.Min.X <= p.X && p.X <= r.Max.X && r.Min.Y <= p.Y && p.Y <= r.Max.Y
+}
+
+//  Returns true if r and r2 share at least one point.
+func (r Rect) Intersects(r2 Rect) bool {
+	if r.Empty() || r2.Empty() {
+		return false
+	}
+	return r.Min.X <= r2.Max.X && r2.Min.X <= r.Max.X &&
+		r.Min.Y <= r2.Max.Y && r2.Min.Y <= r.Max.Y
+}
+
+//  The smallest rectangle containing r and p.
+func (r Rect) Add(p Point) Rect {
+	return Rect{
+		Point{math.Min(r.Min.X, p.X), math.Min(r.Min.Y, p.Y)},
+		Point{math.Max(r.Max.X, p.X), math.Max(r.Max.Y, p.Y)},
+	}
+}
+
+//  The smallest rectangle containing both r and r2.
+func (r Rect) Union(r2 Rect) Rect {
+	if r.Empty() {
+		return r2
+	} else if r2.Empty() {
+		return r
+	}
+	return r.Add(r2.Min).Add(r2.Max)
+}
+
+//  The largest rectangle contained in both r and r2. The result may be
+//  empty.
+func (r Rect) Intersect(r2 Rect) Rect {
+	return Rect{
+		Point{math.Max(r.Min.X, r2.Min.X), math.Max(r.Min.Y, r2.Min.Y)},
+		Point{math.Min(r.Max.X, r2.Max.X), math.Min(r.Max.Y, r2.Max.Y)},
+	}
+}
+
+//  Shrink r by d on every side, as image.Rectangle.Inset. A negative d
+//  grows r.
+func (r Rect) Inset(d float64) Rect {
+	return Rect{r.Min.Add(Point{d, d}), r.Max.Sub(Point{d, d})}
+}
+
+//  The four sides of r, counter-clockwise from the bottom.
+func (r Rect) Segments() []Segment {
+	var (
+		a = r.Min
+		b = Point{r.Max.X, r.Min.Y}
+		c = r.Max
+		d = Point{r.Min.X, r.Max.Y}
+	)
+	return []Segment{{a, b}, {b, c}, {c, d}, {d, a}}
+}
+
+//  A line segment between the points A and B.
+type Segment struct{ A, B Point }
+
+func (s Segment) Length() float64 {
+	return s.B.Sub(s.A).Norm()
+}
+func (s Segment) Bounds() Rect {
+	return Bounds(s.A, s.B)
+}
+
+//  The point on s nearest to p.
+func (s Segment) ClosestPoint(p Point) Point {
+	var (
+		d      = s.B.Sub(s.A)
+		length = d.Dot(d)
+	)
+	if length == 0 {
+		return s.A
+	}
+	var t = p.Sub(s.A).Dot(d) / length
+	if t <= 0 {
+		return s.A
+	} else if t >= 1 {
+		return s.B
+	}
+	return s.A.Add(d.Scale(t))
+}
+
+//  The Euclidean distance from p to the nearest point of s.
+func (s Segment) Distance(p Point) float64 {
+	return p.Sub(s.ClosestPoint(p)).Norm()
+}
+
+//  Compute the point at which s and s2 cross. The second return value is
+//  false if the segments do not meet. Collinear, overlapping segments
+//  report the endpoint of the overlap nearest to s.A.
+func (s Segment) Intersect(s2 Segment) (Point, bool) {
+	var (
+		r     = s.B.Sub(s.A)
+		q     = s2.B.Sub(s2.A)
+		denom = r.Cross(q)
+		diff  = s2.A.Sub(s.A)
+	)
+	if math.Abs(denom) < PointApproximationGap {
+		if math.Abs(diff.Cross(r)) >= PointApproximationGap {
+			return Inf(), false // Parallel and not collinear.
+		}
+		var length = r.Dot(r)
+		if length == 0 {
+			if s2.Distance(s.A) < PointApproximationGap {
+				return s.A, true
+			}
+			return Inf(), false
+		}
+		var (
+			t0 = diff.Dot(r) / length
+			t1 = s2.B.Sub(s.A).Dot(r) / length
+		)
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t1 < 0 || t0 > 1 {
+			return Inf(), false
+		}
+		return s.A.Add(r.Scale(math.Max(t0, 0))), true
+	}
+	var (
+		t = diff.Cross(q) / denom
+		u = diff.Cross(r) / denom
+	)
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Inf(), false
+	}
+	return s.A.Add(r.Scale(t)), true
+}
+
+//  Returns true if s and s2 share at least one point.
+func (s Segment) Intersects(s2 Segment) bool {
+	var _, ok = s.Intersect(s2)
+	return ok
+}
+
+//  Returns true if any point of s lies inside r or on its boundary.
+func (s Segment) IntersectsRect(r Rect) bool {
+	if r.Empty() || !s.Bounds().Intersects(r) {
+		return false
+	}
+	if r.Contains(s.A) || r.Contains(s.B) {
+		return true
+	}
+	for _, side := range r.Segments() {
+		if s.Intersects(side) {
+			return true
+		}
+	}
+	return false
+}
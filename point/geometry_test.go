@@ -0,0 +1,63 @@
+/*
+File: geometry_test.go
+Created: Sun Oct 18 22:14:32 UTC 2026
+*/
+
+package point
+
+import (
+	"testing"
+)
+
+func TestRectUnion(T *testing.T) {
+	var r = Bounds(Point{1, 2}, Point{-1, 5})
+	if r.Min != (Point{-1, 2}) || r.Max != (Point{1, 5}) {
+		T.Errorf("wrong bounds %v", r)
+	}
+	if u := EmptyRect().Union(r); u != r {
+		T.Errorf("union with empty rect changed %v to %v", r, u)
+	}
+	if !r.Contains(Point{0, 3}) || r.Contains(Point{2, 3}) {
+		T.Errorf("bad containment for %v", r)
+	}
+	if !r.Intersects(Rect{Point{1, 5}, Point{3, 7}}) {
+		T.Errorf("touching rectangles do not intersect")
+	}
+	if r.Intersects(EmptyRect()) {
+		T.Errorf("empty rectangle intersects %v", r)
+	}
+	if in := r.Inset(0.5); in.Min != (Point{-0.5, 2.5}) || in.Max != (Point{0.5, 4.5}) {
+		T.Errorf("inset of %v is %v", r, in)
+	}
+	if out := r.Inset(-1); out.Min != (Point{-2, 1}) || out.Max != (Point{2, 6}) {
+		T.Errorf("negative inset of %v is %v", r, out)
+	}
+}
+
+func TestSegmentIntersect(T *testing.T) {
+	var (
+		s1 = Segment{Point{0, 0}, Point{2, 2}}
+		s2 = Segment{Point{0, 2}, Point{2, 0}}
+		s3 = Segment{Point{3, 0}, Point{3, 5}}
+		s4 = Segment{Point{1, 1}, Point{4, 4}}
+	)
+	if p, ok := s1.Intersect(s2); !ok || !p.ApproxEqual(Point{1, 1}) {
+		T.Errorf("crossing segments: %v %v", p, ok)
+	}
+	if _, ok := s1.Intersect(s3); ok {
+		T.Errorf("disjoint segments intersect")
+	}
+	if p, ok := s1.Intersect(s4); !ok || !p.ApproxEqual(Point{1, 1}) {
+		T.Errorf("overlapping collinear segments: %v %v", p, ok)
+	}
+	if _, ok := s1.Intersect(Segment{Point{1, 0}, Point{3, 2}}); ok {
+		T.Errorf("parallel segments intersect")
+	}
+}
+
+func TestSegmentDistance(T *testing.T) {
+	var s = Segment{Point{0, 0}, Point{4, 0}}
+	approx(T, "interior distance", s.Distance(Point{2, 3}), 3, e)
+	approx(T, "endpoint distance", s.Distance(Point{7, 4}), 5, e)
+	approx(T, "degenerate distance", Segment{}.Distance(Point{3, 4}), 5, e)
+}
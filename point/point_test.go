@@ -17,7 +17,7 @@ var e = 1.0e-12
 func approx(t *testing.T, desc string, expect, value, epsilon float64) {
 	if math.Abs(expect-value) > epsilon {
 		if expect == 0 {
-			t.Errorf("%s is not zero (%g)", desc, expect, value)
+			t.Errorf("%s is not zero (%g)", desc, value)
 		} else {
 			t.Errorf("%s is not %g (%g)", desc, expect, value)
 		}
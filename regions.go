@@ -0,0 +1,96 @@
+/*
+File: regions.go
+Created: Mon Oct 19 00:31:09 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+)
+
+//  Directions to the six tiles adjacent to a tile.
+var tileDirections = hex.EdgeDirections()
+
+//  An empty set of coordinates storing the tiles of h in a bitset.
+func (h *Grid) NewHexSet() *hexcoords.HexSet {
+	return hexcoords.NewBoundedHexSet(hexcoords.Hex{h.ColMin(), h.RowMin()}, hexcoords.Hex{h.ColMax(), h.RowMax()})
+}
+
+//  The coordinates of the tiles connected to the tile at start by a chain of
+//  adjacent tiles, each pair of which satisfies same. The function same is
+//  called with a tile already in the region and an adjacent candidate. The
+//  start tile is first in the result, and tiles appear in order of
+//  increasing distance from it. Returns nil if start is not a tile of h.
+//  Regions extend across wrapping borders (see Topology).
+func (h *Grid) FloodFill(start hexcoords.Hex, same func(a, b *Tile) bool) []hexcoords.Hex {
+	start, ok := h.Wrap(start)
+	if !ok {
+		return nil
+	}
+	var (
+		seen   = h.NewHexSet()
+		region = []hexcoords.Hex{start}
+	)
+	seen.Add(start)
+	for i := 0; i < len(region); i++ {
+		var tile = h.GetTile(region[i])
+		h.eachAdjacent(region[i], func(adj hexcoords.Hex) {
+			if !seen.Contains(adj) && same(tile, h.GetTile(adj)) {
+				seen.Add(adj)
+				region = append(region, adj)
+			}
+		})
+	}
+	return region
+}
+
+//  Partition the tiles satisfying pred into maximal groups of adjacent
+//  tiles. Components are ordered by their first tile in column-major order.
+func (h *Grid) Components(pred func(*Tile) bool) [][]hexcoords.Hex {
+	var (
+		seen       = h.NewHexSet()
+		components [][]hexcoords.Hex
+		same       = func(a, b *Tile) bool { return pred(b) }
+	)
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			var c = hexcoords.Hex{u, v}
+			if seen.Contains(c) || !pred(h.GetTile(c)) {
+				continue
+			}
+			var component = h.FloodFill(c, same)
+			for _, member := range component {
+				seen.Add(member)
+			}
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+//  The edges separating the tiles of region from tiles not in region,
+//  including edges on the border of the grid. Each edge appears once, in
+//  the order the tiles of region are given.
+func (h *Grid) Boundary(region []hexcoords.Hex) []*Edge {
+	var (
+		in       = h.NewHexSet()
+		tiles    []hexcoords.Hex
+		boundary []*Edge
+	)
+	for _, c := range region {
+		if c, ok := h.Wrap(c); ok && in.Add(c) {
+			tiles = append(tiles, c)
+		}
+	}
+	for _, c := range tiles {
+		for k, edge := range h.GetEdges(c) {
+			var adj, ok = h.Wrap(c.Adjacent(tileDirections[k]))
+			if !ok || !in.Contains(adj) {
+				boundary = append(boundary, edge)
+			}
+		}
+	}
+	return boundary
+}
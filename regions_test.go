@@ -0,0 +1,62 @@
+/*
+File: regions_test.go
+Created: Mon Oct 19 00:31:09 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestFloodFillAndComponents(T *testing.T) {
+	var (
+		h     = NewGrid(7, 7, 1, 0, 0, 0)
+		black = []hexcoords.Hex{{0, 0}, {0, 1}, {1, 1}, {-3, -3}}
+	)
+	for _, c := range black {
+		h.SetTileValue(c, 1)
+	}
+	var region = h.FloodFill(hexcoords.Hex{0, 0}, func(a, b *Tile) bool { return a.Value == b.Value })
+	if len(region) != 3 || region[0] != (hexcoords.Hex{0, 0}) {
+		T.Errorf("flood fill region %v", region)
+	}
+	if h.FloodFill(hexcoords.Hex{10, 0}, func(a, b *Tile) bool { return true }) != nil {
+		T.Errorf("flood fill outside the grid")
+	}
+	var components = h.Components(func(t *Tile) bool { return t.Value == 1 })
+	if len(components) != 2 || len(components[0]) != 1 || len(components[1]) != 3 {
+		T.Errorf("components %v", components)
+	}
+	var white = h.Components(func(t *Tile) bool { return t.Value == 0 })
+	if len(white) != 1 || len(white[0]) != 49-len(black) {
+		T.Errorf("%d white components", len(white))
+	}
+}
+
+func TestBoundary(T *testing.T) {
+	var (
+		h      = NewGrid(7, 7, 1, 0, 0, 0)
+		c      = hexcoords.Hex{0, 0}
+		single = h.Boundary([]hexcoords.Hex{c})
+		pair   = h.Boundary([]hexcoords.Hex{c, c.Adjacent(hex.N)})
+	)
+	if len(single) != 6 {
+		T.Errorf("%d edges around one tile", len(single))
+	}
+	if len(pair) != 10 {
+		T.Errorf("%d edges around two tiles", len(pair))
+	}
+	for _, e := range pair {
+		if e.Hex.IsIdentical(c.Edges(hex.N)[0]) {
+			T.Errorf("shared edge %v on boundary", e.Hex)
+		}
+	}
+	var corner = h.Boundary([]hexcoords.Hex{{h.ColMin(), h.RowMin()}})
+	if len(corner) != 6 {
+		T.Errorf("%d edges around corner tile", len(corner))
+	}
+}
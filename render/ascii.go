@@ -0,0 +1,185 @@
+/*
+File: ascii.go
+Created: Mon Oct 19 03:58:40 UTC 2026
+*/
+
+/*
+Package render draws a hexgrid.Grid for people to look at.
+
+ASCII draws a grid as text for terminals, logs and golden-file tests. Every
+tile is drawn as a hexagon with an optional label. The characters drawn for
+edges and vertices may be replaced with markers chosen from their values.
+*/
+package render
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"io"
+	"strings"
+)
+
+//  ASCII renders grids as text. Each tile is a hexagon eleven characters
+//  wide and five lines high:
+//
+//	  +-----+
+//	 /       \
+//	+  label  +
+//	 \       /
+//	  +-----+
+//
+//  Adjacent tiles share their edges and vertices. North is up, and odd
+//  columns are drawn half a tile higher than even columns. The zero value
+//  draws unlabeled hexagons.
+type ASCII struct {
+	//  The label of a tile. Lines of the label are separated by "\n".
+	//  Up to three lines are centered in the tile; the middle line holds
+	//  nine characters and the others seven. Longer lines are truncated.
+	Label func(t *hexgrid.Tile) string
+
+	//  A marker drawn in place of the characters of an edge, or 0 to draw
+	//  the edge normally.
+	EdgeMark func(e *hexgrid.Edge) rune
+
+	//  A marker drawn in place of the '+' of a vertex, or 0 to draw '+'.
+	VertexMark func(v *hexgrid.Vertex) rune
+}
+
+//  The placement of the parts of a tile relative to the top left corner of
+//  its hexagon, in characters and lines.
+const (
+	asciiColStep = 8
+	asciiRowStep = 4
+	asciiWidth   = 11
+	asciiHeight  = 5
+)
+
+//  The position of vertex K.
+var asciiVertices = [6][2]int{{2, 4}, {8, 4}, {10, 2}, {8, 0}, {2, 0}, {0, 2}}
+
+//  The positions and default character of edge k.
+var asciiEdges = [6]struct {
+	cells [][2]int
+	ch    rune
+}{
+	{[][2]int{{3, 4}, {4, 4}, {5, 4}, {6, 4}, {7, 4}}, '-'},
+	{[][2]int{{9, 3}}, '/'},
+	{[][2]int{{9, 1}}, '\\'},
+	{[][2]int{{3, 0}, {4, 0}, {5, 0}, {6, 0}, {7, 0}}, '-'},
+	{[][2]int{{1, 1}}, '/'},
+	{[][2]int{{1, 3}}, '\\'},
+}
+
+//  The first column and width of label lines 1, 2 and 3 of a tile.
+var asciiLabelLines = [4][2]int{1: {2, 7}, 2: {1, 9}, 3: {2, 7}}
+
+type canvas [][]rune
+
+func newCanvas(width, height int) canvas {
+	var c = make(canvas, height)
+	for y := range c {
+		c[y] = []rune(strings.Repeat(" ", width))
+	}
+	return c
+}
+
+//  The lines of c with trailing spaces and leading and trailing blank lines
+//  removed.
+func (c canvas) String() string {
+	var lines = make([]string, len(c))
+	for y, line := range c {
+		lines[y] = strings.TrimRight(string(line), " ")
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+//  The top left corner of the hexagon of tile c of h.
+func asciiOrigin(h *hexgrid.Grid, c hexcoords.Hex) (int, int) {
+	var (
+		x = asciiColStep * (c.U - h.ColMin())
+		y = asciiRowStep * (h.RowMax() - c.V)
+	)
+	if c.U&1 == 0 {
+		y += asciiRowStep / 2
+	}
+	return x, y
+}
+
+//  Draw h as text. Wrapping borders are not drawn specially; each tile
+//  within the bounds of h appears once. Label, EdgeMark and VertexMark may
+//  be called more than once for edges and vertices shared by several
+//  tiles.
+func (a ASCII) Render(h *hexgrid.Grid) string {
+	var n, m = h.ColMax() - h.ColMin() + 1, h.RowMax() - h.RowMin() + 1
+	if n <= 0 || m <= 0 {
+		return ""
+	}
+	var c = newCanvas(asciiColStep*(n-1)+asciiWidth, asciiRowStep*m+asciiHeight/2+1)
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			a.drawTile(c, h, hexcoords.Hex{u, v})
+		}
+	}
+	return c.String()
+}
+
+//  Write the result of Render to w.
+func (a ASCII) Write(w io.Writer, h *hexgrid.Grid) error {
+	var _, err = io.WriteString(w, a.Render(h))
+	return err
+}
+
+func (a ASCII) drawTile(c canvas, h *hexgrid.Grid, hc hexcoords.Hex) {
+	var x, y = asciiOrigin(h, hc)
+	for k, e := range hc.Edges(hex.NilDirection) {
+		var ch = asciiEdges[k].ch
+		if a.EdgeMark != nil {
+			if mark := a.EdgeMark(h.GetEdge(e)); mark != 0 {
+				ch = mark
+			}
+		}
+		for _, cell := range asciiEdges[k].cells {
+			c[y+cell[1]][x+cell[0]] = ch
+		}
+	}
+	for k, vc := range hc.Vertices(hex.NilDirection) {
+		var ch = '+'
+		if a.VertexMark != nil {
+			if mark := a.VertexMark(h.GetVertex(vc)); mark != 0 {
+				ch = mark
+			}
+		}
+		c[y+asciiVertices[k][1]][x+asciiVertices[k][0]] = ch
+	}
+	if a.Label == nil {
+		return
+	}
+	var lines = strings.Split(a.Label(h.GetTile(hc)), "\n")
+	if len(lines) > 3 {
+		lines = lines[:3]
+	}
+	var first = 2 - (len(lines)-1)/2
+	for i, line := range lines {
+		var (
+			row   = first + i
+			text  = []rune(line)
+			start = asciiLabelLines[row][0]
+			width = asciiLabelLines[row][1]
+		)
+		if len(text) > width {
+			text = text[:width]
+		}
+		copy(c[y+row][x+start+(width-len(text))/2:], text)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+File: ascii_test.go
+Created: Mon Oct 19 03:58:40 UTC 2026
+*/
+
+package render
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+//  Compare output with the golden file testdata/name, or rewrite the file
+//  when the -update flag is given.
+func golden(T *testing.T, name, output string) {
+	var path = filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, []byte(output), 0644); err != nil {
+			T.Fatal(err)
+		}
+		return
+	}
+	var expected, err = ioutil.ReadFile(path)
+	if err != nil {
+		T.Fatal(err)
+	}
+	if output != string(expected) {
+		T.Errorf("%s differs from output:\n%s", path, output)
+	}
+}
+
+func TestASCIIOutline(T *testing.T) {
+	var h = hexgrid.NewGrid(1, 1, 1, nil, nil, nil)
+	var expected = "" +
+		"  +-----+\n" +
+		" /       \\\n" +
+		"+         +\n" +
+		" \\       /\n" +
+		"  +-----+\n"
+	if s := (ASCII{}).Render(h); s != expected {
+		T.Errorf("single tile rendered\n%s", s)
+	}
+}
+
+func TestASCIICoordinates(T *testing.T) {
+	var (
+		h = hexgrid.NewGrid(3, 3, 1, nil, nil, nil)
+		a = ASCII{Label: func(t *hexgrid.Tile) string { return t.Hex.String() }}
+	)
+	golden(T, "coordinates.txt", a.Render(h))
+}
+
+func TestASCIIMarkers(T *testing.T) {
+	var h = hexgrid.NewGrid(5, 3, 1, nil, nil, nil)
+	h.SetTileValue(hexcoords.Hex{0, 0}, "city\nof\nhexes")
+	h.SetTileValue(hexcoords.Hex{1, 0}, "a very long label")
+	for _, e := range []hexcoords.Edge{{0, 0, 0, 1}, {0, 0, 1, 2}, {1, -1, 3, 4}, {0, 0, 3, 4}} {
+		h.SetEdgeValue(e, "river")
+	}
+	h.SetVertexValue(hexcoords.Vertex{0, 0, 2}, "town")
+	var a = ASCII{
+		Label: func(t *hexgrid.Tile) string {
+			var s, _ = t.Value.(string)
+			return s
+		},
+		EdgeMark: func(e *hexgrid.Edge) rune {
+			if e.Value == "river" {
+				return '~'
+			}
+			return 0
+		},
+		VertexMark: func(v *hexgrid.Vertex) rune {
+			if v.Value == "town" {
+				return '@'
+			}
+			return 0
+		},
+	}
+	golden(T, "markers.txt", a.Render(h))
+}
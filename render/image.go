@@ -0,0 +1,88 @@
+/*
+File: image.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package render
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+//  Image renders grids as raster images. The zero value draws white
+//  hexagons outlined in black at one pixel per unit of the grid's plane.
+type Image struct {
+	//  Pixels per unit of the grid's plane. Zero means 1.
+	Scale float64
+
+	//  The fill color of a tile, or nil for white.
+	Fill func(t *hexgrid.Tile) color.Color
+
+	//  The color of an edge, or nil for the default black outline.
+	EdgeColor func(e *hexgrid.Edge) color.Color
+}
+
+//  Draw h. Each pixel takes the color of an edge passing within a pixel
+//  of its center, or else of the tile containing its center.
+//  Pixels outside the grid are transparent.
+func (im Image) Render(h *hexgrid.Grid) *image.RGBA {
+	var (
+		pr   = newProjection(h, im.Scale, 2)
+		W, H = pr.size()
+		img  = image.NewRGBA(image.Rect(0, 0, int(math.Ceil(W)), int(math.Ceil(H))))
+		line = 0.75 / pr.scale
+	)
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			var (
+				p = pr.invert(float64(x)+0.5, float64(y)+0.5)
+				c = h.HexAt(p)
+			)
+			if !h.WithinBounds(c) {
+				// Outlines extend slightly outside the grid.
+				c = h.NearestTile(p)
+			}
+			var hp = h.GetHex(c)
+			if hp.Distance(p) > line {
+				continue
+			}
+			img.Set(x, y, im.pixel(h, c, hp, p, line))
+		}
+	}
+	return img
+}
+
+//  The color at p, which is within or near the hexagon hp of tile c.
+func (im Image) pixel(h *hexgrid.Grid, c hexcoords.Hex, hp *hexgrid.HexPoints, p point.Point, line float64) color.Color {
+	for k, seg := range hp.Segments() {
+		if seg.Distance(p) > line {
+			continue
+		}
+		if im.EdgeColor != nil {
+			if col := im.EdgeColor(h.GetEdge(c.Edges(hex.NilDirection)[k])); col != nil {
+				return col
+			}
+		}
+		return color.Black
+	}
+	if im.Fill != nil {
+		if col := im.Fill(h.GetTile(c)); col != nil {
+			return col
+		}
+	}
+	return color.White
+}
+
+//  Write h to w as a PNG image.
+func (im Image) Write(w io.Writer, h *hexgrid.Grid) error {
+	return png.Encode(w, im.Render(h))
+}
@@ -0,0 +1,58 @@
+/*
+File: image_test.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package render
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestImage(T *testing.T) {
+	var (
+		h   = hexgrid.NewGrid(3, 3, 1, nil, nil, nil)
+		red = color.RGBA{0xff, 0, 0, 0xff}
+		im  = Image{
+			Scale: 20,
+			Fill: func(t *hexgrid.Tile) color.Color {
+				if t.Value != nil {
+					return red
+				}
+				return nil
+			},
+		}
+	)
+	h.SetTileValue(hexcoords.Hex{0, 0}, true)
+	var img = im.Render(h)
+	if b := img.Bounds(); b.Dx() != 120 || b.Dy() != 144 {
+		T.Errorf("image size %v", b)
+	}
+	var center = func(c hexcoords.Hex) color.Color {
+		var x, y = newProjection(h, 20, 2).apply(h.TileCenter(c))
+		return img.At(int(x), int(y))
+	}
+	if col := center(hexcoords.Hex{0, 0}); col != red {
+		T.Errorf("filled tile color %v", col)
+	}
+	if col := center(hexcoords.Hex{1, 0}); col != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		T.Errorf("unfilled tile color %v", col)
+	}
+	if _, _, _, a := img.At(0, 0).RGBA(); a != 0 {
+		T.Errorf("corner outside the grid is not transparent")
+	}
+
+	var buf bytes.Buffer
+	if err := im.Write(&buf, h); err != nil {
+		T.Fatal(err)
+	}
+	if decoded, err := png.Decode(&buf); err != nil || decoded.Bounds() != img.Bounds() {
+		T.Errorf("decoded %v %v", decoded.Bounds(), err)
+	}
+}
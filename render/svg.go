@@ -0,0 +1,184 @@
+/*
+File: svg.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package render
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+)
+
+//  The mapping from the plane of a grid to image coordinates, which have
+//  the y axis pointing down.
+type projection struct {
+	min, max point.Point
+	scale    float64
+	margin   float64
+}
+
+func newProjection(h *hexgrid.Grid, scale, margin float64) projection {
+	if scale <= 0 {
+		scale = 1
+	}
+	var b = h.Bounds()
+	return projection{b.Min, b.Max, scale, margin}
+}
+
+func (pr projection) size() (float64, float64) {
+	return (pr.max.X-pr.min.X)*pr.scale + 2*pr.margin, (pr.max.Y-pr.min.Y)*pr.scale + 2*pr.margin
+}
+
+func (pr projection) apply(p point.Point) (float64, float64) {
+	return (p.X-pr.min.X)*pr.scale + pr.margin, (pr.max.Y-p.Y)*pr.scale + pr.margin
+}
+
+func (pr projection) invert(x, y float64) point.Point {
+	return point.Point{(x-pr.margin)/pr.scale + pr.min.X, pr.max.Y - (y-pr.margin)/pr.scale}
+}
+
+//  SVG renders grids as scalable vector graphics. The zero value draws
+//  white hexagons outlined in black at one pixel per unit of the grid's
+//  plane.
+type SVG struct {
+	//  Pixels per unit of the grid's plane. Zero means 1.
+	Scale float64
+
+	//  The fill color of a tile as an SVG color ("#7cfc00", "navy"), or
+	//  "" for white.
+	Fill func(t *hexgrid.Tile) string
+
+	//  The label drawn at the center of a tile, or "" for none.
+	Label func(t *hexgrid.Tile) string
+
+	//  The color of an edge, or "" for the default black outline. Colored
+	//  edges are drawn over the outline with a wider stroke.
+	EdgeColor func(e *hexgrid.Edge) string
+
+	//  The color of a dot drawn on a vertex, or "" for none.
+	VertexColor func(v *hexgrid.Vertex) string
+}
+
+//  Write h to w as an SVG document.
+func (s SVG) Write(w io.Writer, h *hexgrid.Grid) error {
+	var (
+		pr   = newProjection(h, s.Scale, 2)
+		bw   = bufio.NewWriter(w)
+		r    = pr.scale * h.Radius()
+		W, H = pr.size()
+	)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\" viewBox=\"0 0 %.2f %.2f\">\n",
+		math.Ceil(W), math.Ceil(H), W, H)
+	fmt.Fprintf(bw, "<g stroke=\"black\" stroke-width=\"%.2f\">\n", math.Max(1, r/16))
+	eachTile(h, func(c hexcoords.Hex) {
+		var (
+			t    = h.GetTile(c)
+			fill = "white"
+		)
+		if s.Fill != nil {
+			if f := s.Fill(t); f != "" {
+				fill = f
+			}
+		}
+		fmt.Fprint(bw, "<polygon points=\"")
+		for k, p := range h.GetHex(c) {
+			if k > 0 {
+				fmt.Fprint(bw, " ")
+			}
+			var x, y = pr.apply(p)
+			fmt.Fprintf(bw, "%.2f,%.2f", x, y)
+		}
+		fmt.Fprintf(bw, "\" fill=\"%s\"/>\n", html.EscapeString(fill))
+	})
+	fmt.Fprintln(bw, "</g>")
+	if s.EdgeColor != nil {
+		fmt.Fprintf(bw, "<g stroke-width=\"%.2f\" stroke-linecap=\"round\">\n", math.Max(2, r/6))
+		eachEdge(h, func(e hexcoords.Edge) {
+			var color = s.EdgeColor(h.GetEdge(e))
+			if color == "" {
+				return
+			}
+			var (
+				v1, v2 = e.Ends()
+				x1, y1 = pr.apply(h.GetVertexPoint(v1))
+				x2, y2 = pr.apply(h.GetVertexPoint(v2))
+			)
+			fmt.Fprintf(bw, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke=\"%s\"/>\n",
+				x1, y1, x2, y2, html.EscapeString(color))
+		})
+		fmt.Fprintln(bw, "</g>")
+	}
+	if s.VertexColor != nil {
+		fmt.Fprintln(bw, "<g>")
+		eachVertex(h, func(vc hexcoords.Vertex) {
+			var color = s.VertexColor(h.GetVertex(vc))
+			if color == "" {
+				return
+			}
+			var x, y = pr.apply(h.GetVertexPoint(vc))
+			fmt.Fprintf(bw, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\" fill=\"%s\"/>\n",
+				x, y, math.Max(2, r/5), html.EscapeString(color))
+		})
+		fmt.Fprintln(bw, "</g>")
+	}
+	if s.Label != nil {
+		fmt.Fprintf(bw, "<g font-family=\"sans-serif\" font-size=\"%.2f\" text-anchor=\"middle\" dominant-baseline=\"middle\">\n", r/2)
+		eachTile(h, func(c hexcoords.Hex) {
+			var label = s.Label(h.GetTile(c))
+			if label == "" {
+				return
+			}
+			var x, y = pr.apply(h.TileCenter(c))
+			fmt.Fprintf(bw, "<text x=\"%.2f\" y=\"%.2f\">%s</text>\n", x, y, html.EscapeString(label))
+		})
+		fmt.Fprintln(bw, "</g>")
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+//  Call fn with each tile of h, column by column.
+func eachTile(h *hexgrid.Grid, fn func(hexcoords.Hex)) {
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			fn(hexcoords.Hex{u, v})
+		}
+	}
+}
+
+//  Call fn once with each edge of h, in canonical coordinates.
+func eachEdge(h *hexgrid.Grid, fn func(hexcoords.Edge)) {
+	var seen = make(map[hexcoords.Edge]bool)
+	eachTile(h, func(c hexcoords.Hex) {
+		for _, e := range c.Edges(hex.NilDirection) {
+			var canon, _ = h.CanonicalEdge(e)
+			if !seen[canon] {
+				seen[canon] = true
+				fn(e)
+			}
+		}
+	})
+}
+
+//  Call fn once with each vertex of h.
+func eachVertex(h *hexgrid.Grid, fn func(hexcoords.Vertex)) {
+	var seen = make(map[hexcoords.Vertex]bool)
+	eachTile(h, func(c hexcoords.Hex) {
+		for _, vc := range c.Vertices(hex.NilDirection) {
+			var canon, _ = h.CanonicalVertex(vc)
+			if !seen[canon] {
+				seen[canon] = true
+				fn(vc)
+			}
+		}
+	})
+}
@@ -0,0 +1,51 @@
+/*
+File: svg_test.go
+Created: Mon Oct 19 04:21:07 UTC 2026
+*/
+
+package render
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSVG(T *testing.T) {
+	var h = hexgrid.NewGrid(3, 3, 1, nil, nil, nil)
+	h.SetTileValue(hexcoords.Hex{0, 0}, "#ff0000")
+	h.SetEdgeValue(hexcoords.Edge{0, 0, 0, 1}, "blue")
+	var (
+		buf bytes.Buffer
+		s   = SVG{
+			Scale:       10,
+			Fill:        func(t *hexgrid.Tile) string { return str(t.Value) },
+			Label:       func(t *hexgrid.Tile) string { return t.Hex.String() },
+			EdgeColor:   func(e *hexgrid.Edge) string { return str(e.Value) },
+			VertexColor: func(v *hexgrid.Vertex) string { return "" },
+		}
+	)
+	if err := s.Write(&buf, h); err != nil {
+		T.Fatal(err)
+	}
+	var out = buf.String()
+	if !strings.HasPrefix(out, "<svg ") || !strings.HasSuffix(out, "</svg>\n") {
+		T.Errorf("not an svg document:\n%s", out)
+	}
+	for _, test := range []struct {
+		s string
+		n int
+	}{{"<polygon ", 9}, {`fill="white"`, 8}, {`fill="#ff0000"`, 1}, {"<line ", 1}, {"<circle ", 0}, {"<text ", 9}, {">(-1,-1)<", 1}} {
+		if n := strings.Count(out, test.s); n != test.n {
+			T.Errorf("%d occurrences of %q, expected %d", n, test.s, test.n)
+		}
+	}
+}
+
+func str(value hexgrid.Value) string {
+	var s, _ = value.(string)
+	return s
+}
@@ -0,0 +1,278 @@
+/*
+File: settlers.go
+Created: Mon Oct 19 01:44:18 UTC 2026
+*/
+
+/*
+Package settlers generates randomized resource boards in the style of the
+Settlers of Catan.
+
+A board is a hexagon of land tiles centered on the origin of a hexgrid.Grid.
+Each land tile has a terrain and, unless it is a desert, a number token.
+Tiles of the grid outside the hexagon have nil values and may be treated as
+sea.
+
+Boards are generated from a Config and a seed. The same Config and seed
+always produce the same board.
+*/
+package settlers
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"errors"
+	"math/rand"
+)
+
+//  The kind of land of a tile.
+type Terrain int
+
+const (
+	Desert Terrain = iota
+	Hills
+	Forest
+	Mountains
+	Fields
+	Pasture
+)
+
+var terrainNames = []string{
+	Desert:    "desert",
+	Hills:     "hills",
+	Forest:    "forest",
+	Mountains: "mountains",
+	Fields:    "fields",
+	Pasture:   "pasture",
+}
+
+func (t Terrain) String() string {
+	if t < 0 || int(t) >= len(terrainNames) {
+		return "unknown"
+	}
+	return terrainNames[t]
+}
+
+//  Returns true if tiles of terrain t produce resources and so receive a
+//  number token.
+func (t Terrain) Produces() bool {
+	return t != Desert
+}
+
+//  The value of a land tile of a generated grid. Number is 0 for tiles
+//  that do not produce.
+type Tile struct {
+	Terrain Terrain
+	Number  int
+}
+
+//  A Constraint reports whether an assignment of tiles to coordinates is
+//  acceptable.
+type Constraint func(board map[hexcoords.Hex]Tile) bool
+
+//  Require that no two adjacent tiles both have a number in nums. The
+//  common rule that red numbers may not touch is NoAdjacentNumbers(6, 8).
+func NoAdjacentNumbers(nums ...int) Constraint {
+	var restricted = make(map[int]bool, len(nums))
+	for _, n := range nums {
+		restricted[n] = true
+	}
+	return func(board map[hexcoords.Hex]Tile) bool {
+		return noAdjacent(board, func(t Tile) bool { return restricted[t.Number] })
+	}
+}
+
+//  Require that no two adjacent tiles have the same number token.
+func NoAdjacentSameNumber() Constraint {
+	return func(board map[hexcoords.Hex]Tile) bool {
+		for c, t := range board {
+			if t.Number == 0 {
+				continue
+			}
+			for _, adj := range c.Adjacents(hex.NilDirection) {
+				if other, ok := board[adj]; ok && other.Number == t.Number {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+//  Require that no two adjacent tiles have terrain t.
+func NoAdjacentTerrain(t Terrain) Constraint {
+	return func(board map[hexcoords.Hex]Tile) bool {
+		return noAdjacent(board, func(tile Tile) bool { return tile.Terrain == t })
+	}
+}
+
+//  Returns false if two adjacent tiles of board satisfy pred.
+func noAdjacent(board map[hexcoords.Hex]Tile, pred func(Tile) bool) bool {
+	for c, t := range board {
+		if !pred(t) {
+			continue
+		}
+		for _, adj := range c.Adjacents(hex.NilDirection) {
+			if other, ok := board[adj]; ok && pred(other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//  Config describes the boards to generate.
+type Config struct {
+	//  The number of rings of land around the center tile. The standard
+	//  board has radius 2 (19 tiles).
+	Radius int
+
+	//  The number of tiles of each terrain. The counts must sum to the
+	//  number of land tiles, 3*Radius*(Radius+1)+1.
+	Terrains map[Terrain]int
+
+	//  Number tokens, one for each producing tile.
+	Numbers []int
+
+	//  Conditions every generated board satisfies.
+	Constraints []Constraint
+
+	//  The number of random boards tried before giving up. Zero means
+	//  DefaultAttempts.
+	Attempts int
+
+	//  The apothem of tiles in the generated grid (see hexgrid.NewGrid).
+	//  Zero means 1.
+	TileRadius float64
+}
+
+//  The number of boards tried when Config.Attempts is zero.
+const DefaultAttempts = 10000
+
+var (
+	ErrTileCount   = errors.New("terrain counts do not match the number of tiles")
+	ErrTokenCount  = errors.New("number tokens do not match the number of producing tiles")
+	ErrConstraints = errors.New("no board satisfying the constraints was found")
+)
+
+//  The configuration of the standard board for three or four players, with
+//  red numbers (6 and 8) kept apart.
+func Standard() Config {
+	return Config{
+		Radius: 2,
+		Terrains: map[Terrain]int{
+			Desert:    1,
+			Hills:     3,
+			Forest:    4,
+			Mountains: 3,
+			Fields:    4,
+			Pasture:   4,
+		},
+		Numbers:     []int{2, 3, 3, 4, 4, 5, 5, 6, 6, 8, 8, 9, 9, 10, 10, 11, 11, 12},
+		Constraints: []Constraint{NoAdjacentNumbers(6, 8)},
+	}
+}
+
+//  The coordinates of the land tiles of a board with the given radius, in
+//  column-major order.
+func LandCoords(radius int) []hexcoords.Hex {
+	var (
+		center = hexcoords.Hex{0, 0}
+		coords []hexcoords.Hex
+	)
+	for u := -radius; u <= radius; u++ {
+		for v := -radius; v <= radius; v++ {
+			var c = hexcoords.Hex{u, v}
+			if center.Distance(c) <= radius {
+				coords = append(coords, c)
+			}
+		}
+	}
+	return coords
+}
+
+//  Generate a board from cfg using the given seed. The grid has 2*Radius+1
+//  columns and rows; its land tiles hold Tile values.
+func Generate(cfg Config, seed int64) (*hexgrid.Grid, error) {
+	var board, err = Assign(cfg, seed)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		size   = 2*cfg.Radius + 1
+		radius = cfg.TileRadius
+	)
+	if radius == 0 {
+		radius = 1
+	}
+	var init = hexgrid.TileInitializer(func(c hexcoords.Hex) hexgrid.Value {
+		if t, ok := board[c]; ok {
+			return t
+		}
+		return nil
+	})
+	return hexgrid.NewGrid(size, size, radius, init, nil, nil), nil
+}
+
+//  Assign terrains and numbers to the land coordinates of a board from cfg
+//  without building a grid.
+func Assign(cfg Config, seed int64) (map[hexcoords.Hex]Tile, error) {
+	var (
+		coords    = LandCoords(cfg.Radius)
+		terrains  []Terrain
+		producing int
+	)
+	for t := Desert; int(t) < len(terrainNames); t++ {
+		for i := 0; i < cfg.Terrains[t]; i++ {
+			terrains = append(terrains, t)
+			if t.Produces() {
+				producing++
+			}
+		}
+	}
+	if len(terrains) != len(coords) {
+		return nil, ErrTileCount
+	}
+	if len(cfg.Numbers) != producing {
+		return nil, ErrTokenCount
+	}
+
+	var (
+		rng      = rand.New(rand.NewSource(seed))
+		numbers  = append([]int(nil), cfg.Numbers...)
+		attempts = cfg.Attempts
+	)
+	if attempts == 0 {
+		attempts = DefaultAttempts
+	}
+	for attempt := 0; attempt < attempts; attempt++ {
+		rng.Shuffle(len(terrains), func(i, j int) { terrains[i], terrains[j] = terrains[j], terrains[i] })
+		rng.Shuffle(len(numbers), func(i, j int) { numbers[i], numbers[j] = numbers[j], numbers[i] })
+		var (
+			board = make(map[hexcoords.Hex]Tile, len(coords))
+			next  int
+		)
+		for k, c := range coords {
+			var tile = Tile{Terrain: terrains[k]}
+			if tile.Terrain.Produces() {
+				tile.Number = numbers[next]
+				next++
+			}
+			board[c] = tile
+		}
+		if satisfies(board, cfg.Constraints) {
+			return board, nil
+		}
+	}
+	return nil, ErrConstraints
+}
+
+func satisfies(board map[hexcoords.Hex]Tile, constraints []Constraint) bool {
+	for _, ok := range constraints {
+		if !ok(board) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+/*
+File: settlers_test.go
+Created: Mon Oct 19 01:44:18 UTC 2026
+*/
+
+package settlers
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestLandCoords(T *testing.T) {
+	for radius, n := range []int{1, 7, 19, 37} {
+		if coords := LandCoords(radius); len(coords) != n {
+			T.Errorf("radius %d: %d tiles", radius, len(coords))
+		}
+	}
+}
+
+func TestGenerateStandard(T *testing.T) {
+	var g, err = Generate(Standard(), 42)
+	if err != nil {
+		T.Fatal(err)
+	}
+	var (
+		counts = make(map[Terrain]int)
+		tokens = make(map[int]int)
+	)
+	for _, c := range LandCoords(2) {
+		var tile, ok = g.TileValue(c).(Tile)
+		if !ok {
+			T.Fatalf("tile %v has value %v", c, g.TileValue(c))
+		}
+		counts[tile.Terrain]++
+		tokens[tile.Number]++
+		if tile.Number != 6 && tile.Number != 8 {
+			continue
+		}
+		for _, adj := range c.Adjacents(hex.NilDirection) {
+			if other, ok := g.TileValue(adj).(Tile); ok && (other.Number == 6 || other.Number == 8) {
+				T.Errorf("red numbers at %v and %v", c, adj)
+			}
+		}
+	}
+	for t, n := range Standard().Terrains {
+		if counts[t] != n {
+			T.Errorf("%d %v tiles, expected %d", counts[t], t, n)
+		}
+	}
+	if tokens[0] != 1 || tokens[6] != 2 || tokens[2] != 1 {
+		T.Errorf("token counts %v", tokens)
+	}
+	if g.TileValue(hexcoords.Hex{2, 2}) != nil {
+		T.Errorf("corner of the grid is land")
+	}
+}
+
+func TestGenerateReproducible(T *testing.T) {
+	var a, _ = Assign(Standard(), 7)
+	var b, _ = Assign(Standard(), 7)
+	for c, t := range a {
+		if b[c] != t {
+			T.Fatalf("boards from the same seed differ at %v", c)
+		}
+	}
+}
+
+func TestGenerateErrors(T *testing.T) {
+	var cfg = Standard()
+	cfg.Numbers = cfg.Numbers[1:]
+	if _, err := Generate(cfg, 1); err != ErrTokenCount {
+		T.Errorf("error %v", err)
+	}
+	cfg = Standard()
+	cfg.Radius = 3
+	if _, err := Generate(cfg, 1); err != ErrTileCount {
+		T.Errorf("error %v", err)
+	}
+	cfg = Standard()
+	cfg.Attempts = 5
+	cfg.Constraints = append(cfg.Constraints, func(map[hexcoords.Hex]Tile) bool { return false })
+	if _, err := Generate(cfg, 1); err != ErrConstraints {
+		T.Errorf("error %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+File: spatial.go
+Created: Sun Oct 18 22:40:51 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"math"
+)
+
+/*
+Spatial queries.
+
+Tiles lie on a regular lattice, so the tiles near any point of the plane can
+be found by arithmetic on the point's coordinates. No tree is built or
+maintained; each query computes the range of columns and rows it may touch
+and tests only the tiles in that range. Vertex and edge queries examine the
+corners and sides of those tiles.
+
+Vertices and edges are reported by their canonical coordinates (see
+hexcoords.Vertex.Canonical and hexcoords.Edge.Canonical) and each appears
+at most once in a result.
+*/
+
+//  The distance from the center of a tile to any of its corners.
+func (h *Grid) circumradius() float64 {
+	return h.radius / math.Cos(hex.TriangleAngle)
+}
+
+//  The coordinates of the hexagon containing p. The result is not
+//  necessarily within the bounds of h. Points on the boundary between two
+//  hexagons are assigned to either one of them.
+func (h *Grid) HexAt(p point.Point) hexcoords.Hex {
+	var (
+		u0    = int(math.Floor(p.X/h.horizontalSpacing() + 0.5))
+		best  hexcoords.Hex
+		bestd = math.Inf(1)
+	)
+	// The hexagon containing p is the one with the nearest center.
+	for u := u0 - 1; u <= u0+1; u++ {
+		var (
+			v = int(math.Floor((p.Y-h.verticalOffset(u))/h.verticalSpacing() + 0.5))
+			c = hexcoords.Hex{u, v}
+			d = h.TileCenter(c).Sub(p).Norm()
+		)
+		if d < bestd {
+			best, bestd = c, d
+		}
+	}
+	return best
+}
+
+//  The coordinates of all tiles within the bounds of h whose hexagon may
+//  intersect r, in column-major order.
+func (h *Grid) hexesNearRect(r point.Rect) []hexcoords.Hex {
+	if r.Empty() {
+		return nil
+	}
+	var (
+		hs   = h.horizontalSpacing()
+		vs   = h.verticalSpacing()
+		bigr = h.circumradius()
+		umin = imax(h.ColMin(), int(math.Floor((r.Min.X-bigr)/hs)))
+		umax = imin(h.ColMax(), int(math.Ceil((r.Max.X+bigr)/hs)))
+		near []hexcoords.Hex
+	)
+	for u := umin; u <= umax; u++ {
+		var (
+			off  = h.verticalOffset(u)
+			vmin = imax(h.RowMin(), int(math.Floor((r.Min.Y-h.radius-off)/vs)))
+			vmax = imin(h.RowMax(), int(math.Ceil((r.Max.Y+h.radius-off)/vs)))
+		)
+		for v := vmin; v <= vmax; v++ {
+			near = append(near, hexcoords.Hex{u, v})
+		}
+	}
+	return near
+}
+
+func circleBounds(center point.Point, d float64) point.Rect {
+	return point.Rect{center, center}.Inset(-d)
+}
+
+//  The coordinates of tiles in h whose hexagon intersects r.
+func (h *Grid) TilesInRect(r point.Rect) []hexcoords.Hex {
+	var tiles []hexcoords.Hex
+	for _, c := range h.hexesNearRect(r) {
+		if h.GetHex(c).IntersectsRect(r) {
+			tiles = append(tiles, c)
+		}
+	}
+	return tiles
+}
+
+//  The coordinates of tiles in h whose hexagon comes within distance d of
+//  center.
+func (h *Grid) TilesInCircle(center point.Point, d float64) []hexcoords.Hex {
+	var tiles []hexcoords.Hex
+	for _, c := range h.hexesNearRect(circleBounds(center, d)) {
+		if h.GetHex(c).Distance(center) <= d {
+			tiles = append(tiles, c)
+		}
+	}
+	return tiles
+}
+
+//  The coordinates of the tile in h nearest to p. If p lies inside the
+//  grid this is the tile containing p.
+func (h *Grid) NearestTile(p point.Point) hexcoords.Hex {
+	var c = h.HexAt(p)
+	if h.WithinBounds(c) {
+		return c
+	}
+	// Clamp to the grid and search the neighborhood of the clamped tile.
+	var (
+		u     = imin(imax(c.U, h.ColMin()), h.ColMax())
+		v     = imin(imax(c.V, h.RowMin()), h.RowMax())
+		best  = hexcoords.Hex{u, v}
+		bestd = h.GetHex(best).Distance(p)
+	)
+	for du := -1; du <= 1; du++ {
+		for dv := -1; dv <= 1; dv++ {
+			var adj = hexcoords.Hex{u + du, v + dv}
+			if !h.WithinBounds(adj) {
+				continue
+			}
+			if d := h.GetHex(adj).Distance(p); d < bestd {
+				best, bestd = adj, d
+			}
+		}
+	}
+	return best
+}
+
+//  Call fn once for each distinct corner of the given tiles.
+func (h *Grid) eachVertexOf(tiles []hexcoords.Hex, fn func(hexcoords.Vertex, point.Point)) {
+	var seen = make(map[hexcoords.Vertex]bool)
+	for _, c := range tiles {
+		var points = h.GetHex(c)
+		for _, vc := range c.Vertices(hex.NilDirection) {
+			var canon = vc.Canonical()
+			if seen[canon] {
+				continue
+			}
+			seen[canon] = true
+			fn(canon, points[vc.K])
+		}
+	}
+}
+
+//  Call fn once for each distinct side of the given tiles.
+func (h *Grid) eachEdgeOf(tiles []hexcoords.Hex, fn func(hexcoords.Edge, point.Segment)) {
+	var seen = make(map[hexcoords.Edge]bool)
+	for _, c := range tiles {
+		var points = h.GetHex(c)
+		for _, ec := range c.Edges(hex.NilDirection) {
+			var canon = ec.Canonical()
+			if seen[canon] {
+				continue
+			}
+			seen[canon] = true
+			fn(canon, point.Segment{points[ec.K], points[ec.L]})
+		}
+	}
+}
+
+//  The canonical coordinates of vertices in h lying inside r.
+func (h *Grid) VerticesInRect(r point.Rect) []hexcoords.Vertex {
+	var vertices []hexcoords.Vertex
+	h.eachVertexOf(h.hexesNearRect(r), func(vc hexcoords.Vertex, p point.Point) {
+		if r.Contains(p) {
+			vertices = append(vertices, vc)
+		}
+	})
+	return vertices
+}
+
+//  The canonical coordinates of vertices in h within distance d of center.
+func (h *Grid) VerticesInCircle(center point.Point, d float64) []hexcoords.Vertex {
+	var vertices []hexcoords.Vertex
+	h.eachVertexOf(h.hexesNearRect(circleBounds(center, d)), func(vc hexcoords.Vertex, p point.Point) {
+		if p.Sub(center).Norm() <= d {
+			vertices = append(vertices, vc)
+		}
+	})
+	return vertices
+}
+
+//  The canonical coordinates of the vertex in h nearest to p.
+func (h *Grid) NearestVertex(p point.Point) hexcoords.Vertex {
+	var (
+		best  hexcoords.Vertex
+		bestd = math.Inf(1)
+	)
+	h.eachVertexOf(h.nearestTiles(p), func(vc hexcoords.Vertex, q point.Point) {
+		if d := q.Sub(p).Norm(); d < bestd {
+			best, bestd = vc, d
+		}
+	})
+	return best
+}
+
+//  The canonical coordinates of edges in h with at least one point inside
+//  r.
+func (h *Grid) EdgesInRect(r point.Rect) []hexcoords.Edge {
+	var edges []hexcoords.Edge
+	h.eachEdgeOf(h.hexesNearRect(r), func(ec hexcoords.Edge, s point.Segment) {
+		if s.IntersectsRect(r) {
+			edges = append(edges, ec)
+		}
+	})
+	return edges
+}
+
+//  The canonical coordinates of edges in h which come within distance d of
+//  center.
+func (h *Grid) EdgesInCircle(center point.Point, d float64) []hexcoords.Edge {
+	var edges []hexcoords.Edge
+	h.eachEdgeOf(h.hexesNearRect(circleBounds(center, d)), func(ec hexcoords.Edge, s point.Segment) {
+		if s.Distance(center) <= d {
+			edges = append(edges, ec)
+		}
+	})
+	return edges
+}
+
+//  The canonical coordinates of the edge in h nearest to p.
+func (h *Grid) NearestEdge(p point.Point) hexcoords.Edge {
+	var (
+		best  hexcoords.Edge
+		bestd = math.Inf(1)
+	)
+	h.eachEdgeOf(h.nearestTiles(p), func(ec hexcoords.Edge, s point.Segment) {
+		if d := s.Distance(p); d < bestd {
+			best, bestd = ec, d
+		}
+	})
+	return best
+}
+
+//  The tile nearest p along with its neighbors in h. The nearest vertex and
+//  nearest edge to p are always corners and sides of these tiles.
+func (h *Grid) nearestTiles(p point.Point) []hexcoords.Hex {
+	var (
+		c     = h.NearestTile(p)
+		tiles = []hexcoords.Hex{c}
+	)
+	for _, adj := range c.Adjacents(hex.NilDirection) {
+		if h.WithinBounds(adj) {
+			tiles = append(tiles, adj)
+		}
+	}
+	return tiles
+}
+
+func imin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
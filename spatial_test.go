@@ -0,0 +1,126 @@
+/*
+File: spatial_test.go
+Created: Sun Oct 18 22:40:51 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"math/rand"
+	"testing"
+)
+
+var sgrid = NewGrid(9, 7, 10, nil, nil, nil)
+
+func randomPoint(rng *rand.Rand, r point.Rect) point.Point {
+	return point.Point{
+		r.Min.X + rng.Float64()*r.Width(),
+		r.Min.Y + rng.Float64()*r.Height(),
+	}
+}
+
+func TestGridSharedObjects(T *testing.T) {
+	for u := sgrid.ColMin(); u <= sgrid.ColMax(); u++ {
+		for v := sgrid.RowMin(); v <= sgrid.RowMax(); v++ {
+			var c = hexcoords.Hex{u, v}
+			for _, dir := range hex.EdgeDirections() {
+				var adj = c.Adjacents(dir)[0]
+				if !sgrid.WithinBounds(adj) {
+					continue
+				}
+				var (
+					e1 = sgrid.GetEdge(c.Edges(dir)[0])
+					e2 = sgrid.GetEdge(adj.Edges(dir.Inverse())[0])
+				)
+				if e1 != e2 {
+					T.Errorf("tiles %v and %v do not share edge %v", c, adj, dir)
+				}
+			}
+			for _, vc := range c.Vertices(hex.NilDirection) {
+				for _, ident := range vc.IdenticalVertices() {
+					if sgrid.WithinBounds(ident.Hex()) && sgrid.GetVertex(ident) != sgrid.GetVertex(vc) {
+						T.Errorf("vertices %v and %v are not shared", vc, ident)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestHexAt(T *testing.T) {
+	var rng = rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		var (
+			p = randomPoint(rng, sgrid.Bounds())
+			c = sgrid.HexAt(p)
+		)
+		if sgrid.WithinBounds(c) && !sgrid.GetHex(c).Contains(p) {
+			T.Errorf("point %v is not inside hex %v", p, c)
+		}
+	}
+}
+
+func TestTilesInRect(T *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(2))
+		b   = sgrid.Bounds().Inset(-20)
+	)
+	for i := 0; i < 50; i++ {
+		var (
+			r     = point.Bounds(randomPoint(rng, b), randomPoint(rng, b))
+			found = make(map[hexcoords.Hex]bool)
+		)
+		for _, c := range sgrid.TilesInRect(r) {
+			found[c] = true
+		}
+		for _, tile := range sgrid.t {
+			if sgrid.GetHex(tile.Hex).IntersectsRect(r) != found[tile.Hex] {
+				T.Errorf("rect %v: tile %v reported %v", r, tile.Hex, found[tile.Hex])
+			}
+		}
+	}
+}
+
+func TestEdgesInCircle(T *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(3))
+		center = randomPoint(rng, sgrid.Bounds())
+		d      = 25.0
+		found  = make(map[hexcoords.Edge]bool)
+	)
+	for _, ec := range sgrid.EdgesInCircle(center, d) {
+		if found[ec] {
+			T.Errorf("edge %v reported twice", ec)
+		}
+		found[ec] = true
+	}
+	for _, tile := range sgrid.t {
+		var hp = sgrid.GetHex(tile.Hex)
+		for _, ec := range tile.Hex.Edges(hex.NilDirection) {
+			var within = (point.Segment{hp[ec.K], hp[ec.L]}).Distance(center) <= d
+			if within != found[ec.Canonical()] {
+				T.Errorf("edge %v within %v is %v", ec, d, within)
+			}
+		}
+	}
+}
+
+func TestNearestVertex(T *testing.T) {
+	var rng = rand.New(rand.NewSource(4))
+	for i := 0; i < 200; i++ {
+		var (
+			p    = randomPoint(rng, sgrid.Bounds().Inset(-30))
+			vc   = sgrid.NearestVertex(p)
+			best = sgrid.GetVertexPoint(vc).Sub(p).Norm()
+		)
+		for _, vert := range sgrid.v {
+			if d := vert.Pos.Sub(p).Norm(); d < best-1e-9 {
+				T.Fatalf("vertex %v at %g is nearer %v than %v at %g", vert.Hex, d, p, vc, best)
+			}
+		}
+	}
+}
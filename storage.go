@@ -0,0 +1,267 @@
+/*
+File: storage.go
+Created: Sun Oct 18 23:02:17 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+)
+
+/*
+Flat storage.
+
+Tiles are stored column by column in a slice of n*m elements (see
+tileSlot).
+
+Every vertex is corner 0 or 1 of exactly one tile, and every edge is the S,
+SE or SW side of exactly one tile (see hexcoords.Vertex.Canonical and
+hexcoords.Edge.Canonical). That tile is the owner of the vertex or edge.
+Owners of the grid's vertices and edges lie in the columns ColMin()-1
+through ColMax()+1 and the rows RowMin() through RowMax()+1, so each owner
+is given a fixed number of slots in a flat slice, found by arithmetic on
+its coordinates. A few slots along the border belong to no vertex or edge
+of the grid and are never used.
+
+A grid created by NewGrid keeps its Vertex and Edge objects in dense slices
+and maps each slot to an index in those slices. A compact grid stores
+values directly in their slots.
+*/
+
+const (
+	vertexSlotsPerOwner = 2
+	edgeSlotsPerOwner   = 3
+)
+
+//  Slot of a canonical edge within its owner, by edge direction.
+var edgeOwnerSlot = []int{
+	hex.S:  0,
+	hex.SE: 1,
+	hex.SW: 2,
+}
+
+//  Index of the tile at c in a flat slice of n*m tiles.
+func (h *Grid) tileSlot(c hexcoords.Hex) int {
+	var i, j = h.hexIndex(c)
+	return i*h.m + j
+}
+
+//  Index of the owner tile c in the extended range of owner tiles.
+func (h *Grid) ownerIndex(c hexcoords.Hex) int {
+	return (c.U-h.ColMin()+1)*(h.m+1) + (c.V - h.RowMin())
+}
+func (h *Grid) numOwners() int {
+	return (h.n + 2) * (h.m + 1)
+}
+func (h *Grid) numVertexSlots() int {
+	return vertexSlotsPerOwner * h.numOwners()
+}
+func (h *Grid) numEdgeSlots() int {
+	return edgeSlotsPerOwner * h.numOwners()
+}
+
+//  Returns true if at least one tile incident to vc is within the bounds
+//  of h.
+func (h *Grid) hasVertex(vc hexcoords.Vertex) bool {
+	if h.WithinBounds(h.wrap(vc.Hex())) {
+		return true
+	}
+	for _, ident := range vc.IdenticalVertices() {
+		if h.WithinBounds(h.wrap(ident.Hex())) {
+			return true
+		}
+	}
+	return false
+}
+
+//  Returns true if at least one tile incident to e is within the bounds of
+//  h. The argument must be canonical.
+func (h *Grid) hasEdge(canon hexcoords.Edge) bool {
+	var c = canon.Hex()
+	if h.WithinBounds(h.wrap(c)) {
+		return true
+	}
+	return h.WithinBounds(h.wrap(c.Adjacent(canon.Direction())))
+}
+
+//  Index of the vertex vc in flat vertex storage. The second return value
+//  is false if vc is not a vertex of h.
+func (h *Grid) vertexSlot(vc hexcoords.Vertex) (int, bool) {
+	if !h.hasVertex(vc) {
+		return -1, false
+	}
+	var canon = h.canonicalVertex(vc)
+	return vertexSlotsPerOwner*h.ownerIndex(canon.Hex()) + canon.K, true
+}
+
+//  Index of the edge e in flat edge storage. The second return value is
+//  false if e is not an edge of h.
+func (h *Grid) edgeSlot(e hexcoords.Edge) (int, bool) {
+	// Equivalent to computing e.Canonical() and its direction, but faster.
+	var (
+		dir   = e.Direction()
+		owner = e.Hex()
+	)
+	switch dir {
+	case hex.NilDirection:
+		return -1, false
+	case hex.N, hex.NE, hex.NW:
+		owner = owner.Adjacent(dir)
+		dir = dir.Inverse()
+	}
+	if h.topology != Bounded {
+		owner = h.wrap(owner)
+		if !h.WithinBounds(owner) && !h.WithinBounds(h.wrap(owner.Adjacent(dir))) {
+			return -1, false
+		}
+	} else if !h.WithinBounds(owner) && !h.WithinBounds(owner.Adjacent(dir)) {
+		return -1, false
+	}
+	return edgeSlotsPerOwner*h.ownerIndex(owner) + edgeOwnerSlot[dir], true
+}
+
+//  The range of owner tiles of the vertices and edges of h. Along a
+//  wrapping axis every owner is within the bounds of h.
+func (h *Grid) ownerRange() (min, max hexcoords.Hex) {
+	min = hexcoords.Hex{h.ColMin() - 1, h.RowMin()}
+	max = hexcoords.Hex{h.ColMax() + 1, h.RowMax() + 1}
+	if h.wrapsHorizontally() {
+		min.U, max.U = h.ColMin(), h.ColMax()
+	}
+	if h.wrapsVertically() {
+		max.V = h.RowMax()
+	}
+	return min, max
+}
+
+//  Call fn with the canonical coordinates and slot of each vertex of h.
+func (h *Grid) eachVertexSlot(fn func(hexcoords.Vertex, int)) {
+	var min, max = h.ownerRange()
+	for u := min.U; u <= max.U; u++ {
+		for v := min.V; v <= max.V; v++ {
+			var owner = hexcoords.Hex{u, v}
+			for k := 0; k < vertexSlotsPerOwner; k++ {
+				var vc = hexcoords.Vertex{u, v, k}
+				if h.hasVertex(vc) {
+					fn(vc, vertexSlotsPerOwner*h.ownerIndex(owner)+k)
+				}
+			}
+		}
+	}
+}
+
+//  Call fn with the canonical coordinates and slot of each edge of h.
+func (h *Grid) eachEdgeSlot(fn func(hexcoords.Edge, int)) {
+	var min, max = h.ownerRange()
+	for u := min.U; u <= max.U; u++ {
+		for v := min.V; v <= max.V; v++ {
+			var owner = hexcoords.Hex{u, v}
+			for _, dir := range []hex.Direction{hex.S, hex.SE, hex.SW} {
+				var ec = owner.Edges(dir)[0]
+				if h.hasEdge(ec) {
+					fn(ec, edgeSlotsPerOwner*h.ownerIndex(owner)+edgeOwnerSlot[dir])
+				}
+			}
+		}
+	}
+}
+
+//  The position of the corner k of a tile centered at the origin.
+func (h *Grid) cornerOffset(k int) point.Point {
+	var side = point.Point{h.radius, 0}.Scale(hex.SideRadiusRatio)
+	return point.Point{0, -h.radius}.Sub(side).Rot(float64(k) * hex.RotateAngle)
+}
+
+//  The position of vertex vc computed from the center of its tile.
+func (h *Grid) vertexPos(vc hexcoords.Vertex) point.Point {
+	return h.TileCenter(vc.Hex()).Add(h.cornerOffset(vc.K))
+}
+
+//  A slot index with every slot unused.
+func newSlotIndex(n int) []int32 {
+	var index = make([]int32, n)
+	for i := range index {
+		index[i] = -1
+	}
+	return index
+}
+
+//  Compact storage holds only the values of tiles, vertices and edges.
+type compactStorage struct {
+	tiles    []Value
+	vertices []Value
+	edges    []Value
+}
+
+func (h *Grid) genCompact(tileDefault, vertexDefault, edgeDefault interface{}) {
+	var s = new(compactStorage)
+	s.tiles = make([]Value, h.n*h.m)
+	for u := h.ColMin(); u <= h.ColMax(); u++ {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			var c = hexcoords.Hex{u, v}
+			s.tiles[h.tileSlot(c)] = initialTileValue(tileDefault, c)
+		}
+	}
+	s.vertices = make([]Value, h.numVertexSlots())
+	h.eachVertexSlot(func(vc hexcoords.Vertex, slot int) {
+		s.vertices[slot] = initialVertexValue(vertexDefault, vc)
+	})
+	s.edges = make([]Value, h.numEdgeSlots())
+	h.eachEdgeSlot(func(ec hexcoords.Edge, slot int) {
+		var v1, v2 *Vertex
+		if isEdgeInitializer(edgeDefault) {
+			var vc1, vc2 = ec.Ends()
+			v1, v2 = h.compactVertex(s, vc1), h.compactVertex(s, vc2)
+		}
+		s.edges[slot] = initialEdgeValue(edgeDefault, ec, v1, v2)
+	})
+	h.compact = s
+}
+
+//  A Vertex object for vc built from compact storage. The coordinates of
+//  the result are canonical.
+func (h *Grid) compactVertex(s *compactStorage, vc hexcoords.Vertex) *Vertex {
+	var slot, ok = h.vertexSlot(vc)
+	if !ok {
+		return nil
+	}
+	var canon = h.canonicalVertex(vc)
+	return &Vertex{Hex: canon, Pos: h.vertexPos(canon), Value: s.vertices[slot]}
+}
+
+//  A compact grid with the same dimensions and values as h. Values are
+//  copied shallowly.
+func (h *Grid) compactCopy() *Grid {
+	var (
+		cp = newGridTopology(h.n, h.m, h.radius, h.topology)
+		s  = &compactStorage{
+			tiles:    make([]Value, h.n*h.m),
+			vertices: make([]Value, h.numVertexSlots()),
+			edges:    make([]Value, h.numEdgeSlots()),
+		}
+	)
+	if h.compact != nil {
+		copy(s.tiles, h.compact.tiles)
+		copy(s.vertices, h.compact.vertices)
+		copy(s.edges, h.compact.edges)
+	} else {
+		for slot := range h.t {
+			s.tiles[slot] = h.t[slot].Value
+		}
+		for slot, i := range h.vertexIndex {
+			if i >= 0 {
+				s.vertices[slot] = h.v[i].Value
+			}
+		}
+		for slot, i := range h.edgeIndex {
+			if i >= 0 {
+				s.edges[slot] = h.e[i].Value
+			}
+		}
+	}
+	cp.compact = s
+	return cp
+}
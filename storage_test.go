@@ -0,0 +1,99 @@
+/*
+File: storage_test.go
+Created: Sun Oct 18 23:02:17 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestStorageSlotCounts(T *testing.T) {
+	var (
+		nverts  int
+		nedges  int
+		vslots  = make(map[int]bool)
+		eslots  = make(map[int]bool)
+		compact = NewCompactGrid(7, 5, 1, nil, nil, nil)
+	)
+	compact.eachVertexSlot(func(vc hexcoords.Vertex, slot int) {
+		if vslots[slot] {
+			T.Errorf("vertex slot %d used twice", slot)
+		}
+		vslots[slot] = true
+		nverts++
+	})
+	compact.eachEdgeSlot(func(ec hexcoords.Edge, slot int) {
+		if eslots[slot] {
+			T.Errorf("edge slot %d used twice", slot)
+		}
+		eslots[slot] = true
+		nedges++
+	})
+	if nverts != compact.expectedNumVertices() {
+		T.Errorf("%d vertex slots, expected %d", nverts, compact.expectedNumVertices())
+	}
+	if nedges != compact.expectedNumEdges() {
+		T.Errorf("%d edge slots, expected %d", nedges, compact.expectedNumEdges())
+	}
+}
+
+func TestCompactGridValues(T *testing.T) {
+	var (
+		tileInit = TileInitializer(func(c hexcoords.Hex) Value { return c.U*100 + c.V })
+		vertInit = func(vc hexcoords.Vertex) Value { return vc.Canonical() }
+		edgeInit = func(e hexcoords.Edge, v1, v2 *Vertex) Value { return e.Canonical() }
+		eager    = NewGrid(7, 5, 1, tileInit, vertInit, edgeInit)
+		compact  = NewCompactGrid(7, 5, 1, tileInit, vertInit, edgeInit)
+	)
+	for _, tile := range eager.t {
+		var c = tile.Hex
+		if compact.TileValue(c) != eager.TileValue(c) {
+			T.Errorf("tile %v: compact %v, eager %v", c, compact.TileValue(c), eager.TileValue(c))
+		}
+		if !compact.GetTile(c).Pos.ApproxEqual(tile.Pos) {
+			T.Errorf("tile %v: different positions", c)
+		}
+		for _, vc := range c.Vertices(hex.NilDirection) {
+			if compact.VertexValue(vc) != eager.VertexValue(vc) {
+				T.Errorf("vertex %v: compact %v, eager %v", vc, compact.VertexValue(vc), eager.VertexValue(vc))
+			}
+		}
+		for _, ec := range c.Edges(hex.NilDirection) {
+			if compact.EdgeValue(ec) != eager.EdgeValue(ec) {
+				T.Errorf("edge %v: compact %v, eager %v", ec, compact.EdgeValue(ec), eager.EdgeValue(ec))
+			}
+		}
+	}
+}
+
+func TestSetValues(T *testing.T) {
+	for _, h := range []*Grid{NewGrid(5, 5, 1, 0, 0, 0), NewCompactGrid(5, 5, 1, 0, 0, 0)} {
+		var (
+			c  = hexcoords.Hex{1, 1}
+			vc = hexcoords.Vertex{1, 1, 3}
+			ec = c.Edges(hex.N)[0]
+		)
+		h.SetTileValue(c, 1)
+		h.SetVertexValue(vc, 2)
+		h.SetEdgeValue(ec, 3)
+		if h.TileValue(c) != 1 {
+			T.Errorf("compact=%v: tile value not set", h.IsCompact())
+		}
+		for _, ident := range vc.IdenticalVertices() {
+			if h.VertexValue(ident) != 2 {
+				T.Errorf("compact=%v: vertex %v value not set", h.IsCompact(), ident)
+			}
+		}
+		if h.EdgeValue(c.Adjacents(hex.N)[0].Edges(hex.S)[0]) != 3 {
+			T.Errorf("compact=%v: shared edge value not set", h.IsCompact())
+		}
+		if h.TileValue(hexcoords.Hex{10, 10}) != nil {
+			T.Errorf("compact=%v: value outside the grid", h.IsCompact())
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+File: noise.go
+Created: Mon Oct 19 02:10:33 UTC 2026
+*/
+
+package terrain
+
+import (
+	"github.com/bmatsuo/hexgrid/point"
+
+	"math"
+)
+
+//  Seeded two-dimensional value noise. Random values in [0,1) are assigned
+//  to the points of the integer lattice and smoothly interpolated between.
+type noise struct {
+	seed    uint64
+	octaves int
+}
+
+//  A well mixed 64-bit hash of x (the splitmix64 finalizer).
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+//  The random value of lattice point (i,j) in octave o.
+func (n noise) lattice(i, j int64, o int) float64 {
+	var h = mix(n.seed ^ mix(uint64(i)^mix(uint64(j)^mix(uint64(o)))))
+	return float64(h>>11) / (1 << 53)
+}
+
+func smooth(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+//  Value noise at p for a single octave.
+func (n noise) single(p point.Point, o int) float64 {
+	var (
+		x0 = math.Floor(p.X)
+		y0 = math.Floor(p.Y)
+		i  = int64(x0)
+		j  = int64(y0)
+		tx = smooth(p.X - x0)
+		ty = smooth(p.Y - y0)
+	)
+	return lerp(
+		lerp(n.lattice(i, j, o), n.lattice(i+1, j, o), tx),
+		lerp(n.lattice(i, j+1, o), n.lattice(i+1, j+1, o), tx),
+		ty)
+}
+
+//  Fractal noise at p in [0,1). Each octave doubles the frequency and
+//  halves the amplitude of the previous one.
+func (n noise) at(p point.Point) float64 {
+	var (
+		sum, total float64
+		amplitude  = 1.0
+	)
+	for o := 0; o < n.octaves; o++ {
+		sum += amplitude * n.single(p, o)
+		total += amplitude
+		p = p.Scale(2)
+		amplitude /= 2
+	}
+	return sum / total
+}
@@ -0,0 +1,292 @@
+/*
+File: terrain.go
+Created: Mon Oct 19 02:10:33 UTC 2026
+*/
+
+/*
+Package terrain procedurally generates maps on a hexgrid.Grid.
+
+Elevation and moisture are sampled from seeded fractal noise at the center
+of each tile, and each tile is classified into a biome. Rivers start at high
+vertices and flow downhill along edges, from vertex to vertex, until they
+reach the sea or can descend no further. Rivers meeting merge.
+
+The results are delivered through the grid's initializers: tiles hold Tile
+values and river edges hold River values. Edges without a river, and all
+vertices, hold nil.
+*/
+package terrain
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"math/rand"
+)
+
+//  A broad class of terrain determined by elevation and moisture.
+type Biome int
+
+const (
+	Ocean Biome = iota
+	Beach
+	Desert
+	Grassland
+	Forest
+	Rainforest
+	Mountain
+	Snow
+)
+
+var biomeNames = []string{
+	Ocean:      "ocean",
+	Beach:      "beach",
+	Desert:     "desert",
+	Grassland:  "grassland",
+	Forest:     "forest",
+	Rainforest: "rainforest",
+	Mountain:   "mountain",
+	Snow:       "snow",
+}
+
+func (b Biome) String() string {
+	if b < 0 || int(b) >= len(biomeNames) {
+		return "unknown"
+	}
+	return biomeNames[b]
+}
+
+//  The value of every tile of a generated grid. Elevation and Moisture lie
+//  in [0,1).
+type Tile struct {
+	Elevation float64
+	Moisture  float64
+	Biome     Biome
+}
+
+//  The value of an edge carrying a river. Flow is the number of river
+//  sources upstream of the edge, so it grows where rivers join.
+type River struct {
+	Flow int
+}
+
+//  Config describes the maps to generate.
+type Config struct {
+	//  The apothem of tiles in the generated grid (see hexgrid.NewGrid).
+	//  Zero means 1.
+	TileRadius float64
+
+	//  The approximate width of hills and valleys, in tiles. Zero means 8.
+	Scale float64
+
+	//  The number of noise octaves. More octaves give rougher terrain.
+	//  Zero means 4.
+	Octaves int
+
+	//  Tiles with lower elevation are ocean.
+	SeaLevel float64
+
+	//  The maximum number of rivers.
+	Rivers int
+
+	//  The minimum elevation of a river's source.
+	RiverElevation float64
+}
+
+//  A configuration producing land with scattered seas and a few rivers.
+func DefaultConfig() Config {
+	return Config{
+		TileRadius:     1,
+		Scale:          8,
+		Octaves:        4,
+		SeaLevel:       0.4,
+		Rivers:         8,
+		RiverElevation: 0.6,
+	}
+}
+
+//  Classify a tile with the given elevation and moisture.
+func Classify(elevation, moisture, seaLevel float64) Biome {
+	switch {
+	case elevation < seaLevel:
+		return Ocean
+	case elevation < seaLevel+0.03:
+		return Beach
+	case elevation > 0.8:
+		if moisture < 0.5 {
+			return Mountain
+		}
+		return Snow
+	case moisture < 0.2:
+		return Desert
+	case moisture < 0.5:
+		return Grassland
+	case moisture < 0.8:
+		return Forest
+	}
+	return Rainforest
+}
+
+//  A Generator samples terrain for one configuration and seed.
+type Generator struct {
+	cfg       Config
+	seed      int64
+	elevation noise
+	moisture  noise
+}
+
+//  A generator for cfg. The same configuration and seed always produce the
+//  same terrain.
+func New(cfg Config, seed int64) *Generator {
+	if cfg.TileRadius == 0 {
+		cfg.TileRadius = 1
+	}
+	if cfg.Scale == 0 {
+		cfg.Scale = 8
+	}
+	if cfg.Octaves == 0 {
+		cfg.Octaves = 4
+	}
+	return &Generator{
+		cfg:       cfg,
+		seed:      seed,
+		elevation: noise{seed: mix(uint64(seed)), octaves: cfg.Octaves},
+		moisture:  noise{seed: mix(^uint64(seed)), octaves: cfg.Octaves},
+	}
+}
+
+//  The position of p in noise space.
+func (gen *Generator) noisePoint(p point.Point) point.Point {
+	return p.Scale(1 / (gen.cfg.Scale * 2 * gen.cfg.TileRadius))
+}
+
+//  The elevation of the point p of the plane of a generated grid.
+func (gen *Generator) Elevation(p point.Point) float64 {
+	return gen.elevation.at(gen.noisePoint(p))
+}
+
+//  The moisture of the point p of the plane of a generated grid.
+func (gen *Generator) Moisture(p point.Point) float64 {
+	return gen.moisture.at(gen.noisePoint(p))
+}
+
+//  Generate an n by m grid. Its tiles hold Tile values and its river edges
+//  hold River values.
+func (gen *Generator) Generate(n, m int) *hexgrid.Grid {
+	var tileInit, edgeInit = gen.Initializers(n, m)
+	return hexgrid.NewGrid(n, m, gen.cfg.TileRadius, tileInit, nil, edgeInit)
+}
+
+//  Initializers producing the terrain of an n by m grid, for use with
+//  hexgrid.NewGrid or hexgrid.NewCompactGrid with the same dimensions and
+//  the configured tile radius.
+func (gen *Generator) Initializers(n, m int) (hexgrid.TileInitializer, hexgrid.EdgeInitializer) {
+	var (
+		geom  = hexgrid.NewCompactGrid(n, m, gen.cfg.TileRadius, nil, nil, nil)
+		tiles = make(map[hexcoords.Hex]Tile, n*m)
+	)
+	for u := geom.ColMin(); u <= geom.ColMax(); u++ {
+		for v := geom.RowMin(); v <= geom.RowMax(); v++ {
+			var (
+				c = hexcoords.Hex{u, v}
+				p = geom.TileCenter(c)
+				t = Tile{Elevation: gen.Elevation(p), Moisture: gen.Moisture(p)}
+			)
+			t.Biome = Classify(t.Elevation, t.Moisture, gen.cfg.SeaLevel)
+			tiles[c] = t
+		}
+	}
+	var rivers = gen.rivers(geom, tiles)
+	var tileInit = func(c hexcoords.Hex) hexgrid.Value {
+		return tiles[c]
+	}
+	var edgeInit = func(e hexcoords.Edge, v1, v2 *hexgrid.Vertex) hexgrid.Value {
+		if r, ok := rivers[e.Canonical()]; ok {
+			return r
+		}
+		return nil
+	}
+	return tileInit, edgeInit
+}
+
+//  The elevation of a vertex is the mean elevation of its tiles within the
+//  grid. The second return value is false if the vertex touches the sea.
+func vertexElevation(tiles map[hexcoords.Hex]Tile, vc hexcoords.Vertex) (float64, bool) {
+	var (
+		sum  float64
+		n    int
+		land = true
+	)
+	for _, c := range vc.Incidents() {
+		if t, ok := tiles[c]; ok {
+			sum += t.Elevation
+			n++
+			land = land && t.Biome != Ocean
+		}
+	}
+	return sum / float64(n), land
+}
+
+//  Trace rivers downhill from random high vertices.
+func (gen *Generator) rivers(geom *hexgrid.Grid, tiles map[hexcoords.Hex]Tile) map[hexcoords.Edge]River {
+	var (
+		rivers  = make(map[hexcoords.Edge]River)
+		seen    = make(map[hexcoords.Vertex]bool)
+		sources []hexcoords.Vertex
+	)
+	for u := geom.ColMin(); u <= geom.ColMax(); u++ {
+		for v := geom.RowMin(); v <= geom.RowMax(); v++ {
+			for _, vc := range (hexcoords.Hex{u, v}).Vertices(hex.NilDirection) {
+				var canon = vc.Canonical()
+				if seen[canon] {
+					continue
+				}
+				seen[canon] = true
+				if e, land := vertexElevation(tiles, canon); land && e >= gen.cfg.RiverElevation {
+					sources = append(sources, canon)
+				}
+			}
+		}
+	}
+	var rng = rand.New(rand.NewSource(gen.seed))
+	rng.Shuffle(len(sources), func(i, j int) { sources[i], sources[j] = sources[j], sources[i] })
+	if len(sources) > gen.cfg.Rivers {
+		sources = sources[:gen.cfg.Rivers]
+	}
+
+	// A river always descends to the lowest adjacent vertex, so a river
+	// reaching another follows it the rest of the way, adding its flow.
+	for _, vc := range sources {
+		for {
+			var (
+				elev, land = vertexElevation(tiles, vc)
+				next       hexcoords.Vertex
+				nextEdge   hexcoords.Edge
+				found      bool
+			)
+			if !land {
+				break
+			}
+			for _, ident := range vc.IdenticalVertices() {
+				var (
+					adj = hexcoords.Vertex{ident.U, ident.V, hex.VertexIndexClockwise(ident.K)}
+					e   = hexcoords.Edge{ident.U, ident.V, ident.K, adj.K}
+				)
+				if geom.GetEdge(e) == nil {
+					continue
+				}
+				if adjElev, _ := vertexElevation(tiles, adj); adjElev < elev {
+					elev, next, nextEdge, found = adjElev, adj, e.Canonical(), true
+				}
+			}
+			if !found {
+				break
+			}
+			rivers[nextEdge] = River{Flow: rivers[nextEdge].Flow + 1}
+			vc = next
+		}
+	}
+	return rivers
+}
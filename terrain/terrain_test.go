@@ -0,0 +1,100 @@
+/*
+File: terrain_test.go
+Created: Mon Oct 19 02:10:33 UTC 2026
+*/
+
+package terrain
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+	"github.com/bmatsuo/hexgrid/point"
+
+	"testing"
+)
+
+func TestNoiseRange(T *testing.T) {
+	var n = noise{seed: 1, octaves: 4}
+	for x := -5.0; x < 5; x += 0.37 {
+		for y := -5.0; y < 5; y += 0.29 {
+			if v := n.at(point.Point{x, y}); v < 0 || v >= 1 {
+				T.Fatalf("noise %v at (%v,%v)", v, x, y)
+			}
+		}
+	}
+	if n.single(point.Point{2, 3}, 0) != n.lattice(2, 3, 0) {
+		T.Errorf("noise does not interpolate lattice values")
+	}
+}
+
+func TestGenerate(T *testing.T) {
+	var (
+		cfg = DefaultConfig()
+		a   = New(cfg, 99).Generate(21, 15)
+		b   = New(cfg, 99).Generate(21, 15)
+		c   = New(cfg, 100).Generate(21, 15)
+	)
+	var same, rivers = true, 0
+	for u := a.ColMin(); u <= a.ColMax(); u++ {
+		for v := a.RowMin(); v <= a.RowMax(); v++ {
+			var h = hexcoords.Hex{u, v}
+			var tile, ok = a.TileValue(h).(Tile)
+			if !ok {
+				T.Fatalf("tile %v has value %v", h, a.TileValue(h))
+			}
+			if tile != b.TileValue(h) {
+				T.Fatalf("tiles from the same seed differ at %v", h)
+			}
+			same = same && tile == c.TileValue(h)
+			if tile.Biome != Classify(tile.Elevation, tile.Moisture, cfg.SeaLevel) {
+				T.Errorf("tile %v misclassified", h)
+			}
+			for _, e := range h.Edges(hex.NilDirection) {
+				if _, ok := a.EdgeValue(e).(River); ok && e.Canonical().Hex() == h {
+					rivers++
+				}
+			}
+		}
+	}
+	if same {
+		T.Errorf("different seeds produce the same map")
+	}
+	if rivers == 0 {
+		T.Errorf("no rivers generated")
+	}
+}
+
+func TestRiversFlowDownhill(T *testing.T) {
+	var (
+		ti, ei = New(DefaultConfig(), 5).Initializers(31, 21)
+		g      = hexgrid.NewCompactGrid(31, 21, 1, ti, nil, ei)
+		tiles  = make(map[hexcoords.Hex]Tile)
+	)
+	for u := g.ColMin(); u <= g.ColMax(); u++ {
+		for v := g.RowMin(); v <= g.RowMax(); v++ {
+			tiles[hexcoords.Hex{u, v}] = g.TileValue(hexcoords.Hex{u, v}).(Tile)
+		}
+	}
+	for u := g.ColMin(); u <= g.ColMax(); u++ {
+		for v := g.RowMin(); v <= g.RowMax(); v++ {
+			for _, e := range (hexcoords.Hex{u, v}).Edges(hex.NilDirection) {
+				var r, ok = g.EdgeValue(e).(River)
+				if !ok {
+					continue
+				}
+				if r.Flow < 1 {
+					T.Errorf("river edge %v has flow %d", e, r.Flow)
+				}
+				var (
+					v1, v2 = e.Ends()
+					e1, _  = vertexElevation(tiles, v1)
+					e2, _  = vertexElevation(tiles, v2)
+				)
+				if e1 == e2 {
+					T.Errorf("river edge %v is flat", e)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+File: tiled.go
+Created: Mon Oct 19 05:31:44 UTC 2026
+*/
+
+/*
+Package tiled reads and writes hexagonal maps made with the Tiled map
+editor, in its XML (TMX) and JSON (TMJ) formats.
+
+Only hexagonal maps staggered along the x axis, whose hexagons are flat
+sided to the north like the tiles of a hexgrid.Grid, are supported. Tiled
+column x and row y (counting down from the top of the map) are mapped onto
+a hexcoords.Hex so that the columns Tiled staggers, shifting them down by
+half a tile, are the low columns of the grid. A grid needs odd dimensions
+and has fixed column parities, so the grid of a map may have an extra
+column or row of tiles outside the map.
+The tile height of the map, in pixels, is the diameter of the inscribed
+circle of a tile, twice the grid radius.
+
+Tile layers are read with any of Tiled's data encodings (XML, CSV and
+base64, optionally zlib or gzip compressed) and written as CSV in TMX files
+and as arrays in TMJ files. Infinite maps, group layers, object layers and
+the contents of embedded tilesets other than their basic attributes are not
+supported.
+*/
+package tiled
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"errors"
+	"fmt"
+	"math"
+)
+
+//  The largest number of tiles in the grid of a map, including the extra
+//  column and row it may need. Larger maps are rejected before anything is
+//  allocated for their tiles.
+const MaxTiles = 1 << 22
+
+var (
+	ErrOrientation = errors.New("tiled: map is not hexagonal and staggered along the x axis")
+	ErrInfinite    = errors.New("tiled: infinite maps are not supported")
+)
+
+//  A Map is a hexagonal Tiled map.
+type Map struct {
+	//  The size of the map in tiles.
+	Width, Height int
+
+	//  The size of a tile in pixels. HexSideLength is the length of the
+	//  north and south sides of the hexagon.
+	TileWidth, TileHeight int
+	HexSideLength         int
+
+	//  "odd" if odd columns are shifted down by half a tile, "even" if even
+	//  columns are.
+	StaggerIndex string
+
+	Tilesets []Tileset
+	Layers   []Layer
+}
+
+//  A reference to a tileset. Source names the file of an external
+//  tileset; embedded tilesets have a Name and image instead.
+type Tileset struct {
+	FirstGID    uint32
+	Source      string
+	Name        string
+	TileWidth   int
+	TileHeight  int
+	TileCount   int
+	Columns     int
+	Image       string
+	ImageWidth  int
+	ImageHeight int
+}
+
+//  A tile layer. Data holds the global tile IDs (GIDs) of the tiles row by
+//  row, from the top left corner of the map; zero means no tile. The high
+//  bits of a GID are Tiled's flip flags.
+type Layer struct {
+	ID   int
+	Name string
+	Data []uint32
+}
+
+//  Check that m describes a map this package supports.
+func (m *Map) check() error {
+	if m.StaggerIndex != "odd" && m.StaggerIndex != "even" {
+		return fmt.Errorf("tiled: invalid stagger index %q", m.StaggerIndex)
+	}
+	if m.Width <= 0 || m.Height <= 0 {
+		return fmt.Errorf("tiled: invalid map size %dx%d", m.Width, m.Height)
+	}
+	if m.Width > MaxTiles/m.Height {
+		return fmt.Errorf("tiled: map size %dx%d exceeds %d tiles", m.Width, m.Height, MaxTiles)
+	}
+	if m.TileHeight <= 0 {
+		return fmt.Errorf("tiled: invalid tile height %d", m.TileHeight)
+	}
+	for _, layer := range m.Layers {
+		if len(layer.Data) != m.Width*m.Height {
+			return fmt.Errorf("tiled: layer %q has %d tiles, expected %d",
+				layer.Name, len(layer.Data), m.Width*m.Height)
+		}
+	}
+	return nil
+}
+
+//  The placement of a map in a grid: tile (x,y) is at (x+u0, v0-y) in a
+//  grid of n columns and m rows.
+type placement struct {
+	n, m   int
+	u0, v0 int
+}
+
+//  The smallest grid holding the map with its staggered columns low.
+func (m *Map) placement() placement {
+	// With stagger index "even" the odd columns of the map are high, as
+	// are the odd columns of a grid, so u0 must be even.
+	var parity = 0
+	if m.StaggerIndex == "odd" {
+		parity = 1
+	}
+	var rows = m.Height | 1
+	for n := m.Width | 1; ; n += 2 {
+		var colMin = -(n / 2)
+		for u0 := colMin; u0+m.Width <= colMin+n; u0++ {
+			if u0&1 == parity {
+				return placement{n, rows, u0, -(rows / 2) + m.Height - 1}
+			}
+		}
+	}
+}
+
+//  The coordinates of Tiled tile (x,y) in the grid returned by Grid.
+func (m *Map) Hex(x, y int) hexcoords.Hex {
+	var p = m.placement()
+	return hexcoords.Hex{x + p.u0, p.v0 - y}
+}
+
+//  The Tiled column and row of the tile at c of the grid returned by Grid.
+//  The third return value is false if c is not a tile of m.
+func (m *Map) Cell(c hexcoords.Hex) (int, int, bool) {
+	var (
+		p    = m.placement()
+		x, y = c.U - p.u0, p.v0 - c.V
+	)
+	return x, y, 0 <= x && x < m.Width && 0 <= y && y < m.Height
+}
+
+//  A grid holding the tiles of layer i of m. Tile values are the uint32
+//  GIDs of the tiles. Tiles without a tile in the layer, including tiles
+//  outside the map, hold nil. The radius of the grid is half the tile
+//  height of m.
+func (m *Map) Grid(i int) (*hexgrid.Grid, error) {
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	if i < 0 || i >= len(m.Layers) {
+		return nil, fmt.Errorf("tiled: no layer %d", i)
+	}
+	var p = m.placement()
+	if p.n > MaxTiles/p.m {
+		return nil, fmt.Errorf("tiled: grid size %dx%d exceeds %d tiles", p.n, p.m, MaxTiles)
+	}
+	var (
+		g    = hexgrid.NewGrid(p.n, p.m, float64(m.TileHeight)/2, nil, nil, nil)
+		data = m.Layers[i].Data
+	)
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if gid := data[y*m.Width+x]; gid != 0 {
+				g.SetTileValue(m.Hex(x, y), gid)
+			}
+		}
+	}
+	return g, nil
+}
+
+//  A map of the tiles of g with a single layer named name. The tile height
+//  is the diameter of the grid's tiles (twice the radius) times scale, in
+//  pixels. The GID of each tile is given by gid, which may return 0 for no
+//  tile. Wrapping borders of g are not preserved.
+func FromGrid(g *hexgrid.Grid, name string, scale float64, gid func(t *hexgrid.Tile) uint32) *Map {
+	var (
+		height = 2 * g.Radius() * scale
+		side   = height / math.Sqrt(3)
+		m      = &Map{
+			Width:         g.NumCols(),
+			Height:        g.NumRows(),
+			TileHeight:    int(math.Floor(height + 0.5)),
+			TileWidth:     int(math.Floor(2*side + 0.5)),
+			HexSideLength: int(math.Floor(side + 0.5)),
+			StaggerIndex:  "even",
+		}
+	)
+	if g.ColMin()&1 != 0 {
+		m.StaggerIndex = "odd"
+	}
+	var layer = Layer{ID: 1, Name: name, Data: make([]uint32, m.Width*m.Height)}
+	for u := g.ColMin(); u <= g.ColMax(); u++ {
+		for v := g.RowMin(); v <= g.RowMax(); v++ {
+			var x, y, _ = m.Cell(hexcoords.Hex{u, v})
+			layer.Data[y*m.Width+x] = gid(g.GetTile(hexcoords.Hex{u, v}))
+		}
+	}
+	m.Layers = []Layer{layer}
+	return m
+}
@@ -0,0 +1,210 @@
+/*
+File: tiled_test.go
+Created: Mon Oct 19 05:31:44 UTC 2026
+*/
+
+package tiled
+
+import (
+	"github.com/bmatsuo/hexgrid"
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//  A 4x3 map with stagger index odd, as saved by Tiled. Tile GIDs encode
+//  their column and row as 10*(x+1)+y.
+const tmxCSV = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" tiledversion="1.10.2" orientation="hexagonal" renderorder="right-down" width="4" height="3" tilewidth="28" tileheight="24" infinite="0" hexsidelength="14" staggeraxis="x" staggerindex="odd" nextlayerid="2" nextobjectid="1">
+ <tileset firstgid="1" source="terrain.tsx"/>
+ <layer id="1" name="ground" width="4" height="3">
+  <data encoding="csv">
+10,20,30,40,
+11,21,31,41,
+12,22,32,42
+</data>
+ </layer>
+ <objectgroup id="2" name="units"/>
+</map>
+`
+
+//  The same layer encoded as zlib compressed base64, and as XML elements.
+const (
+	zlibData = `eJwNwzESABAMALDOGDGWEf9/oOQuJSK66bE6XF6b0+3zAx0IATk=`
+	xmlData  = `<tile gid="10"/><tile gid="20"/><tile gid="30"/><tile gid="40"/><tile gid="11"/><tile gid="21"/><tile gid="31"/><tile gid="41"/><tile gid="12"/><tile gid="22"/><tile gid="32"/><tile gid="42"/>`
+)
+
+func expectedData() []uint32 {
+	var data []uint32
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			data = append(data, uint32(10*(x+1)+y))
+		}
+	}
+	return data
+}
+
+func TestReadTMX(T *testing.T) {
+	var m, err = ReadTMX(strings.NewReader(tmxCSV))
+	if err != nil {
+		T.Fatal(err)
+	}
+	if m.Width != 4 || m.Height != 3 || m.StaggerIndex != "odd" || len(m.Layers) != 1 {
+		T.Fatalf("read %+v", m)
+	}
+	if !reflect.DeepEqual(m.Tilesets, []Tileset{{FirstGID: 1, Source: "terrain.tsx"}}) {
+		T.Errorf("tilesets %+v", m.Tilesets)
+	}
+	if !reflect.DeepEqual(m.Layers[0].Data, expectedData()) {
+		T.Errorf("layer data %v", m.Layers[0].Data)
+	}
+	var xmlDoc = strings.Replace(tmxCSV, `<data encoding="csv">
+10,20,30,40,
+11,21,31,41,
+12,22,32,42
+</data>`, "<data>"+xmlData+"</data>", 1)
+	if m, err = ReadTMX(strings.NewReader(xmlDoc)); err != nil {
+		T.Fatal(err)
+	}
+	if !reflect.DeepEqual(m.Layers[0].Data, expectedData()) {
+		T.Errorf("xml layer data %v", m.Layers[0].Data)
+	}
+}
+
+func TestDecodeBase64(T *testing.T) {
+	var data, err = decodeBase64(zlibData, "zlib")
+	if err != nil {
+		T.Fatal(err)
+	}
+	if !reflect.DeepEqual(data, expectedData()) {
+		T.Errorf("zlib layer data %v", data)
+	}
+}
+
+//  Tiles adjacent in Tiled must be adjacent in the grid, in the same
+//  direction on screen.
+func TestStagger(T *testing.T) {
+	for _, index := range []string{"odd", "even"} {
+		for _, width := range []int{3, 4, 5, 6} {
+			var m = &Map{Width: width, Height: 4, TileWidth: 28, TileHeight: 24, StaggerIndex: index}
+			for x := 0; x+1 < width; x++ {
+				// With stagger index odd, odd columns are shifted down, so
+				// tile (x,y) with x even has its SE neighbor at (x+1,y).
+				var shifted = (x%2 == 1) == (index == "odd")
+				var dir = hex.NE
+				if !shifted {
+					dir = hex.SE
+				}
+				if d := m.Hex(x, 1).Adjacency(m.Hex(x+1, 1)); d != dir {
+					T.Errorf("%s %d: tile (%d,1) to (%d,1) direction %v, expected %v", index, width, x, x+1, d, dir)
+				}
+			}
+			var g, err = m.Grid(-1)
+			if err == nil {
+				T.Errorf("grid of missing layer")
+			}
+			m.Layers = []Layer{{Data: make([]uint32, width*4)}}
+			if g, err = m.Grid(0); err != nil {
+				T.Fatal(err)
+			}
+			for x := 0; x < width; x++ {
+				for y := 0; y < 4; y++ {
+					var c = m.Hex(x, y)
+					if !g.WithinBounds(c) {
+						T.Errorf("%s %d: tile (%d,%d) at %v outside the grid", index, width, x, y, c)
+					}
+					if x2, y2, ok := m.Cell(c); !ok || x2 != x || y2 != y {
+						T.Errorf("%s %d: cell of %v is (%d,%d)", index, width, c, x2, y2)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGridRoundTrip(T *testing.T) {
+	var m, err = ReadTMX(strings.NewReader(tmxCSV))
+	if err != nil {
+		T.Fatal(err)
+	}
+	g, err := m.Grid(0)
+	if err != nil {
+		T.Fatal(err)
+	}
+	if g.Radius() != 12 {
+		T.Errorf("radius %v", g.Radius())
+	}
+	if gid := g.TileValue(m.Hex(2, 1)); gid != uint32(31) {
+		T.Errorf("tile (2,1) GID %v", gid)
+	}
+
+	var h = hexgrid.NewGrid(5, 3, 1, nil, nil, nil)
+	h.SetTileValue(hexcoords.Hex{-2, 1}, uint32(7))
+	h.SetTileValue(hexcoords.Hex{1, -1}, uint32(9))
+	var gid = func(t *hexgrid.Tile) uint32 {
+		var gid, _ = t.Value.(uint32)
+		return gid
+	}
+	m = FromGrid(h, "ground", 12, gid)
+	if m.TileHeight != 24 || m.TileWidth != 28 || m.HexSideLength != 14 || m.StaggerIndex != "even" {
+		T.Errorf("exported %+v", m)
+	}
+	var buf bytes.Buffer
+	for _, format := range []string{"tmx", "tmj"} {
+		buf.Reset()
+		var read *Map
+		if format == "tmx" {
+			if err = m.WriteTMX(&buf); err == nil {
+				read, err = ReadTMX(&buf)
+			}
+		} else {
+			if err = m.WriteTMJ(&buf); err == nil {
+				read, err = ReadTMJ(&buf)
+			}
+		}
+		if err != nil {
+			T.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(read, m) {
+			T.Errorf("%s: read %+v, expected %+v", format, read, m)
+		}
+		g, err = read.Grid(0)
+		if err != nil {
+			T.Fatal(err)
+		}
+		if g.NumCols() != 5 || g.TileValue(hexcoords.Hex{-2, 1}) != uint32(7) || g.TileValue(hexcoords.Hex{1, -1}) != uint32(9) {
+			T.Errorf("%s: grid tiles differ", format)
+		}
+	}
+}
+
+func TestReadErrors(T *testing.T) {
+	var pointy = strings.Replace(tmxCSV, `staggeraxis="x"`, `staggeraxis="y"`, 1)
+	if _, err := ReadTMX(strings.NewReader(pointy)); err != ErrOrientation {
+		T.Errorf("pointy map: %v", err)
+	}
+	var infinite = strings.Replace(tmxCSV, `infinite="0"`, `infinite="1"`, 1)
+	if _, err := ReadTMX(strings.NewReader(infinite)); err != ErrInfinite {
+		T.Errorf("infinite map: %v", err)
+	}
+	var short = strings.Replace(tmxCSV, "12,22,32,42", "12,22,32", 1)
+	if _, err := ReadTMX(strings.NewReader(short)); err == nil {
+		T.Errorf("short layer read")
+	}
+	// The product of the dimensions overflows to the length of the data.
+	var huge = strings.NewReplacer(
+		`width="4" height="3"`, `width="4294967296" height="4294967296"`,
+		"10,20,30,40,\n11,21,31,41,\n12,22,32,42\n", "").Replace(tmxCSV)
+	if m, err := ReadTMX(strings.NewReader(huge)); err == nil {
+		T.Errorf("map of %dx%d tiles read", m.Width, m.Height)
+	}
+	var wide = &Map{Width: MaxTiles, Height: 1, TileHeight: 24, StaggerIndex: "odd",
+		Layers: []Layer{{Name: "ground", Data: make([]uint32, MaxTiles)}}}
+	if _, err := wide.Grid(0); err == nil {
+		T.Errorf("grid of %d columns created", wide.Width)
+	}
+}
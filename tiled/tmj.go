@@ -0,0 +1,159 @@
+/*
+File: tmj.go
+Created: Mon Oct 19 05:31:44 UTC 2026
+*/
+
+package tiled
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type tmjMap struct {
+	Type          string       `json:"type"`
+	Version       string       `json:"version"`
+	Orientation   string       `json:"orientation"`
+	RenderOrder   string       `json:"renderorder"`
+	Width         int          `json:"width"`
+	Height        int          `json:"height"`
+	TileWidth     int          `json:"tilewidth"`
+	TileHeight    int          `json:"tileheight"`
+	HexSideLength int          `json:"hexsidelength"`
+	StaggerAxis   string       `json:"staggeraxis"`
+	StaggerIndex  string       `json:"staggerindex"`
+	Infinite      bool         `json:"infinite"`
+	NextLayerID   int          `json:"nextlayerid"`
+	NextObjectID  int          `json:"nextobjectid"`
+	Layers        []tmjLayer   `json:"layers"`
+	Tilesets      []tmjTileset `json:"tilesets"`
+}
+
+type tmjTileset struct {
+	FirstGID    uint32 `json:"firstgid"`
+	Source      string `json:"source,omitempty"`
+	Name        string `json:"name,omitempty"`
+	TileWidth   int    `json:"tilewidth,omitempty"`
+	TileHeight  int    `json:"tileheight,omitempty"`
+	TileCount   int    `json:"tilecount,omitempty"`
+	Columns     int    `json:"columns,omitempty"`
+	Image       string `json:"image,omitempty"`
+	ImageWidth  int    `json:"imagewidth,omitempty"`
+	ImageHeight int    `json:"imageheight,omitempty"`
+}
+
+type tmjLayer struct {
+	ID          int             `json:"id"`
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	X           int             `json:"x"`
+	Y           int             `json:"y"`
+	Opacity     float64         `json:"opacity"`
+	Visible     bool            `json:"visible"`
+	Encoding    string          `json:"encoding,omitempty"`
+	Compression string          `json:"compression,omitempty"`
+	Data        json.RawMessage `json:"data,omitempty"`
+}
+
+//  Read a map in Tiled's JSON format. Only tile layers are read.
+func ReadTMJ(r io.Reader) (*Map, error) {
+	var doc tmjMap
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("tiled: %v", err)
+	}
+	if doc.Orientation != "hexagonal" || doc.StaggerAxis != "x" {
+		return nil, ErrOrientation
+	}
+	if doc.Infinite {
+		return nil, ErrInfinite
+	}
+	var m = &Map{
+		Width:         doc.Width,
+		Height:        doc.Height,
+		TileWidth:     doc.TileWidth,
+		TileHeight:    doc.TileHeight,
+		HexSideLength: doc.HexSideLength,
+		StaggerIndex:  doc.StaggerIndex,
+	}
+	for _, ts := range doc.Tilesets {
+		m.Tilesets = append(m.Tilesets, Tileset(ts))
+	}
+	for _, l := range doc.Layers {
+		if l.Type != "tilelayer" {
+			continue
+		}
+		var data, err = l.decode()
+		if err != nil {
+			return nil, fmt.Errorf("tiled: layer %q: %v", l.Name, err)
+		}
+		m.Layers = append(m.Layers, Layer{ID: l.ID, Name: l.Name, Data: data})
+	}
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (l *tmjLayer) decode() ([]uint32, error) {
+	switch l.Encoding {
+	case "", "csv":
+		var gids []uint32
+		return gids, json.Unmarshal(l.Data, &gids)
+	case "base64":
+		var text string
+		if err := json.Unmarshal(l.Data, &text); err != nil {
+			return nil, err
+		}
+		return decodeBase64(text, l.Compression)
+	}
+	return nil, fmt.Errorf("unknown encoding %q", l.Encoding)
+}
+
+//  Write m in Tiled's JSON format.
+func (m *Map) WriteTMJ(w io.Writer) error {
+	if err := m.check(); err != nil {
+		return err
+	}
+	var doc = tmjMap{
+		Type:          "map",
+		Version:       "1.10",
+		Orientation:   "hexagonal",
+		RenderOrder:   "right-down",
+		Width:         m.Width,
+		Height:        m.Height,
+		TileWidth:     m.TileWidth,
+		TileHeight:    m.TileHeight,
+		HexSideLength: m.HexSideLength,
+		StaggerAxis:   "x",
+		StaggerIndex:  m.StaggerIndex,
+		NextLayerID:   m.nextLayerID(),
+		NextObjectID:  1,
+		Layers:        []tmjLayer{},
+		Tilesets:      []tmjTileset{},
+	}
+	for _, t := range m.Tilesets {
+		doc.Tilesets = append(doc.Tilesets, tmjTileset(t))
+	}
+	for _, l := range m.Layers {
+		var data, err = json.Marshal(l.Data)
+		if err != nil {
+			return err
+		}
+		doc.Layers = append(doc.Layers, tmjLayer{
+			ID:      l.ID,
+			Name:    l.Name,
+			Type:    "tilelayer",
+			Width:   m.Width,
+			Height:  m.Height,
+			Opacity: 1,
+			Visible: true,
+			Data:    data,
+		})
+	}
+	var enc = json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	return enc.Encode(doc)
+}
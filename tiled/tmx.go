@@ -0,0 +1,265 @@
+/*
+File: tmx.go
+Created: Mon Oct 19 05:31:44 UTC 2026
+*/
+
+package tiled
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+type tmxMap struct {
+	XMLName       xml.Name     `xml:"map"`
+	Version       string       `xml:"version,attr"`
+	Orientation   string       `xml:"orientation,attr"`
+	RenderOrder   string       `xml:"renderorder,attr"`
+	Width         int          `xml:"width,attr"`
+	Height        int          `xml:"height,attr"`
+	TileWidth     int          `xml:"tilewidth,attr"`
+	TileHeight    int          `xml:"tileheight,attr"`
+	HexSideLength int          `xml:"hexsidelength,attr"`
+	StaggerAxis   string       `xml:"staggeraxis,attr"`
+	StaggerIndex  string       `xml:"staggerindex,attr"`
+	Infinite      int          `xml:"infinite,attr"`
+	NextLayerID   int          `xml:"nextlayerid,attr,omitempty"`
+	Tilesets      []tmxTileset `xml:"tileset"`
+	Layers        []tmxLayer   `xml:"layer"`
+}
+
+type tmxTileset struct {
+	FirstGID   uint32    `xml:"firstgid,attr"`
+	Source     string    `xml:"source,attr,omitempty"`
+	Name       string    `xml:"name,attr,omitempty"`
+	TileWidth  int       `xml:"tilewidth,attr,omitempty"`
+	TileHeight int       `xml:"tileheight,attr,omitempty"`
+	TileCount  int       `xml:"tilecount,attr,omitempty"`
+	Columns    int       `xml:"columns,attr,omitempty"`
+	Image      *tmxImage `xml:"image"`
+}
+
+type tmxImage struct {
+	Source string `xml:"source,attr"`
+	Width  int    `xml:"width,attr,omitempty"`
+	Height int    `xml:"height,attr,omitempty"`
+}
+
+type tmxLayer struct {
+	ID     int     `xml:"id,attr,omitempty"`
+	Name   string  `xml:"name,attr"`
+	Width  int     `xml:"width,attr"`
+	Height int     `xml:"height,attr"`
+	Data   tmxData `xml:"data"`
+}
+
+type tmxData struct {
+	Encoding    string     `xml:"encoding,attr,omitempty"`
+	Compression string     `xml:"compression,attr,omitempty"`
+	Text        string     `xml:",chardata"`
+	Tiles       []tmxTile  `xml:"tile"`
+	Chunks      []struct{} `xml:"chunk"`
+}
+
+type tmxTile struct {
+	GID uint32 `xml:"gid,attr"`
+}
+
+//  Read a map in Tiled's XML format.
+func ReadTMX(r io.Reader) (*Map, error) {
+	var doc tmxMap
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("tiled: %v", err)
+	}
+	if doc.Orientation != "hexagonal" || doc.StaggerAxis != "x" {
+		return nil, ErrOrientation
+	}
+	if doc.Infinite != 0 {
+		return nil, ErrInfinite
+	}
+	var m = &Map{
+		Width:         doc.Width,
+		Height:        doc.Height,
+		TileWidth:     doc.TileWidth,
+		TileHeight:    doc.TileHeight,
+		HexSideLength: doc.HexSideLength,
+		StaggerIndex:  doc.StaggerIndex,
+	}
+	for _, ts := range doc.Tilesets {
+		var t = Tileset{
+			FirstGID:   ts.FirstGID,
+			Source:     ts.Source,
+			Name:       ts.Name,
+			TileWidth:  ts.TileWidth,
+			TileHeight: ts.TileHeight,
+			TileCount:  ts.TileCount,
+			Columns:    ts.Columns,
+		}
+		if ts.Image != nil {
+			t.Image, t.ImageWidth, t.ImageHeight = ts.Image.Source, ts.Image.Width, ts.Image.Height
+		}
+		m.Tilesets = append(m.Tilesets, t)
+	}
+	for _, l := range doc.Layers {
+		if len(l.Data.Chunks) > 0 {
+			return nil, ErrInfinite
+		}
+		var data, err = l.Data.decode()
+		if err != nil {
+			return nil, fmt.Errorf("tiled: layer %q: %v", l.Name, err)
+		}
+		m.Layers = append(m.Layers, Layer{ID: l.ID, Name: l.Name, Data: data})
+	}
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (d *tmxData) decode() ([]uint32, error) {
+	switch d.Encoding {
+	case "":
+		var gids = make([]uint32, len(d.Tiles))
+		for i, t := range d.Tiles {
+			gids[i] = t.GID
+		}
+		return gids, nil
+	case "csv":
+		return decodeCSV(d.Text)
+	case "base64":
+		return decodeBase64(d.Text, d.Compression)
+	}
+	return nil, fmt.Errorf("unknown encoding %q", d.Encoding)
+}
+
+func decodeCSV(text string) ([]uint32, error) {
+	var gids []uint32
+	for _, field := range strings.Split(text, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		var gid, err = strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		gids = append(gids, uint32(gid))
+	}
+	return gids, nil
+}
+
+//  Decode little-endian GIDs encoded in base64, after decompressing them
+//  with "zlib" or "gzip" compression, or none if compression is empty.
+func decodeBase64(text, compression string) ([]uint32, error) {
+	var raw, err = base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = bytes.NewReader(raw)
+	switch compression {
+	case "":
+	case "zlib":
+		if r, err = zlib.NewReader(r); err != nil {
+			return nil, err
+		}
+	case "gzip":
+		if r, err = gzip.NewReader(r); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+	if raw, err = ioutil.ReadAll(r); err != nil {
+		return nil, err
+	}
+	if len(raw)%4 != 0 {
+		return nil, fmt.Errorf("tile data length %d is not a multiple of 4", len(raw))
+	}
+	var gids = make([]uint32, len(raw)/4)
+	for i := range gids {
+		gids[i] = binary.LittleEndian.Uint32(raw[4*i:])
+	}
+	return gids, nil
+}
+
+//  Write m in Tiled's XML format.
+func (m *Map) WriteTMX(w io.Writer) error {
+	if err := m.check(); err != nil {
+		return err
+	}
+	var doc = tmxMap{
+		Version:       "1.10",
+		Orientation:   "hexagonal",
+		RenderOrder:   "right-down",
+		Width:         m.Width,
+		Height:        m.Height,
+		TileWidth:     m.TileWidth,
+		TileHeight:    m.TileHeight,
+		HexSideLength: m.HexSideLength,
+		StaggerAxis:   "x",
+		StaggerIndex:  m.StaggerIndex,
+		NextLayerID:   m.nextLayerID(),
+	}
+	for _, t := range m.Tilesets {
+		var ts = tmxTileset{
+			FirstGID:   t.FirstGID,
+			Source:     t.Source,
+			Name:       t.Name,
+			TileWidth:  t.TileWidth,
+			TileHeight: t.TileHeight,
+			TileCount:  t.TileCount,
+			Columns:    t.Columns,
+		}
+		if t.Image != "" {
+			ts.Image = &tmxImage{t.Image, t.ImageWidth, t.ImageHeight}
+		}
+		doc.Tilesets = append(doc.Tilesets, ts)
+	}
+	for _, l := range m.Layers {
+		var rows = make([]string, m.Height)
+		for y := range rows {
+			var fields = make([]string, m.Width)
+			for x := range fields {
+				fields[x] = strconv.FormatUint(uint64(l.Data[y*m.Width+x]), 10)
+			}
+			rows[y] = strings.Join(fields, ",")
+		}
+		doc.Layers = append(doc.Layers, tmxLayer{
+			ID:     l.ID,
+			Name:   l.Name,
+			Width:  m.Width,
+			Height: m.Height,
+			Data:   tmxData{Encoding: "csv", Text: "\n" + strings.Join(rows, ",\n") + "\n"},
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	var enc = xml.NewEncoder(w)
+	enc.Indent("", " ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+//  An ID greater than the ID of every layer of m.
+func (m *Map) nextLayerID() int {
+	var next = 1
+	for _, l := range m.Layers {
+		if l.ID >= next {
+			next = l.ID + 1
+		}
+	}
+	return next
+}
@@ -0,0 +1,181 @@
+/*
+File: topology.go
+Created: Mon Oct 19 02:41:56 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hexcoords"
+)
+
+//  The topology of a grid determines which borders wrap around to the
+//  opposite side. A grid wrapping horizontally is a cylinder, like the
+//  east-west wrapping maps of many strategy games. A grid wrapping in both
+//  directions is a torus.
+type Topology int
+
+const (
+	Bounded        Topology = 0
+	WrapHorizontal Topology = 1
+	WrapVertical   Topology = 2
+	WrapBoth       Topology = WrapHorizontal | WrapVertical
+)
+
+//  Create an nxm grid like NewGrid with the given topology. A grid that
+//  wraps horizontally must have an even number of columns so that high and
+//  low columns alternate across the seam. Other dimensions may be odd or
+//  even.
+//
+//  Coordinates outside the bounds of a wrapping grid refer to the tile,
+//  vertex or edge they wrap onto. GetTile, TileValue, SetTileValue and the
+//  vertex and edge accessors accept such coordinates; WithinBounds does
+//  not (see Wrap).
+func NewWrappedGrid(n, m int, r float64, topology Topology, tileDefault, vertexDefault, edgeDefault interface{}) *Grid {
+	var h = newGridTopology(n, m, r, topology)
+	h.genHexagons()
+	h.genTiles(tileDefault)
+	h.genVertices(vertexDefault)
+	h.genEdges(edgeDefault) // Must come after genVertices.
+	return h
+}
+
+//  Create a compact grid (see NewCompactGrid) with the given topology (see
+//  NewWrappedGrid).
+func NewCompactWrappedGrid(n, m int, r float64, topology Topology, tileDefault, vertexDefault, edgeDefault interface{}) *Grid {
+	var h = newGridTopology(n, m, r, topology)
+	h.genCompact(tileDefault, vertexDefault, edgeDefault)
+	return h
+}
+
+//  The topology of h.
+func (h *Grid) Topology() Topology {
+	return h.topology
+}
+
+func (h *Grid) wrapsHorizontally() bool {
+	return h.topology&WrapHorizontal != 0
+}
+func (h *Grid) wrapsVertically() bool {
+	return h.topology&WrapVertical != 0
+}
+
+//  x mod n in [0,n).
+func imod(x, n int) int {
+	x %= n
+	if x < 0 {
+		x += n
+	}
+	return x
+}
+
+//  Translate c along the wrapping axes of h into the bounds of h.
+func (h *Grid) wrap(c hexcoords.Hex) hexcoords.Hex {
+	if h.topology == Bounded {
+		return c
+	}
+	if h.wrapsHorizontally() {
+		c.U = h.ColMin() + imod(c.U-h.ColMin(), h.n)
+	}
+	if h.wrapsVertically() {
+		c.V = h.RowMin() + imod(c.V-h.RowMin(), h.m)
+	}
+	return c
+}
+
+//  The coordinates within the bounds of h of the tile c refers to. The
+//  second return value is false if c refers to no tile of h. For a bounded
+//  grid Wrap returns c and WithinBounds(c).
+func (h *Grid) Wrap(c hexcoords.Hex) (hexcoords.Hex, bool) {
+	c = h.wrap(c)
+	return c, h.WithinBounds(c)
+}
+
+//  The canonical coordinates of vc with its owner wrapped.
+func (h *Grid) canonicalVertex(vc hexcoords.Vertex) hexcoords.Vertex {
+	var (
+		canon = vc.Canonical()
+		owner = h.wrap(canon.Hex())
+	)
+	return hexcoords.Vertex{owner.U, owner.V, canon.K}
+}
+
+//  The canonical coordinates of e with its owner wrapped.
+func (h *Grid) canonicalEdge(e hexcoords.Edge) hexcoords.Edge {
+	var (
+		canon = e.Canonical()
+		owner = h.wrap(canon.Hex())
+	)
+	return hexcoords.Edge{owner.U, owner.V, canon.K, canon.L}
+}
+
+//  The canonical coordinates of vertex vc in h (see
+//  hexcoords.Vertex.Canonical). In a wrapping grid the owner tile of the
+//  result is translated within the bounds of the grid along the wrapping
+//  axes, so every representation of a vertex has the same canonical
+//  coordinates. The second return value is false if vc is not a vertex of
+//  h.
+func (h *Grid) CanonicalVertex(vc hexcoords.Vertex) (hexcoords.Vertex, bool) {
+	return h.canonicalVertex(vc), h.hasVertex(vc)
+}
+
+//  The canonical coordinates of edge e in h. See CanonicalVertex.
+func (h *Grid) CanonicalEdge(e hexcoords.Edge) (hexcoords.Edge, bool) {
+	var _, ok = h.edgeSlot(e)
+	return h.canonicalEdge(e), ok
+}
+
+//  Returns true if a and b refer to the same vertex of h, possibly across a
+//  wrapping border.
+func (h *Grid) VerticesIdentical(a, b hexcoords.Vertex) bool {
+	return h.canonicalVertex(a) == h.canonicalVertex(b)
+}
+
+//  Returns true if a and b refer to the same edge of h, possibly across a
+//  wrapping border.
+func (h *Grid) EdgesIdentical(a, b hexcoords.Edge) bool {
+	return h.canonicalEdge(a) == h.canonicalEdge(b)
+}
+
+//  The coordinates of the tiles of h adjacent to the tile at c, wrapped
+//  within the bounds of h, in the order S, SE, NE, N, NW, SW. Directions
+//  leading off a border that does not wrap are omitted.
+func (h *Grid) Adjacents(c hexcoords.Hex) []hexcoords.Hex {
+	var adjs = make([]hexcoords.Hex, 0, len(tileDirections))
+	h.eachAdjacent(c, func(adj hexcoords.Hex) {
+		adjs = append(adjs, adj)
+	})
+	return adjs
+}
+
+//  Call fn with each tile of h adjacent to c, as returned by Adjacents.
+func (h *Grid) eachAdjacent(c hexcoords.Hex, fn func(hexcoords.Hex)) {
+	for _, dir := range tileDirections {
+		if adj, ok := h.Wrap(c.Adjacent(dir)); ok {
+			fn(adj)
+		}
+	}
+}
+
+//  The number of steps between adjacent tiles needed to travel from a to b,
+//  taking the shortest route across wrapping borders.
+func (h *Grid) Distance(a, b hexcoords.Hex) int {
+	a, b = h.wrap(a), h.wrap(b)
+	var best = a.Distance(b)
+	if h.topology == Bounded {
+		return best
+	}
+	var du, dv = []int{0}, []int{0}
+	if h.wrapsHorizontally() {
+		du = []int{-h.n, 0, h.n}
+	}
+	if h.wrapsVertically() {
+		dv = []int{-h.m, 0, h.m}
+	}
+	for _, i := range du {
+		for _, j := range dv {
+			best = imin(best, a.Distance(hexcoords.Hex{b.U + i, b.V + j}))
+		}
+	}
+	return best
+}
@@ -0,0 +1,188 @@
+/*
+File: topology_test.go
+Created: Mon Oct 19 02:41:56 UTC 2026
+*/
+
+package hexgrid
+
+import (
+	"github.com/bmatsuo/hexgrid/hex"
+	"github.com/bmatsuo/hexgrid/hexcoords"
+
+	"testing"
+)
+
+func TestWrappedGridCounts(T *testing.T) {
+	for _, topology := range []Topology{WrapHorizontal, WrapVertical, WrapBoth} {
+		var n, m = 6, 5
+		if topology == WrapVertical {
+			n = 7
+		}
+		var (
+			eager   = NewWrappedGrid(n, m, 1, topology, nil, nil, nil)
+			compact = NewCompactWrappedGrid(n, m, 1, topology, nil, nil, nil)
+			euler   = eager.NumVertices() - eager.NumEdges() + eager.NumTiles()
+		)
+		// Cylinders and tori have Euler characteristic 0.
+		if euler != 0 {
+			T.Errorf("topology %d: V-E+F = %d", topology, euler)
+		}
+		if compact.NumVertices() != eager.NumVertices() || compact.NumEdges() != eager.NumEdges() {
+			T.Errorf("topology %d: compact and eager counts differ", topology)
+		}
+		if topology == WrapBoth && (eager.NumVertices() != 2*n*m || eager.NumEdges() != 3*n*m) {
+			T.Errorf("torus has %d vertices and %d edges", eager.NumVertices(), eager.NumEdges())
+		}
+	}
+}
+
+func TestWrappedGridOddColumns(T *testing.T) {
+	defer func() {
+		if recover() == nil {
+			T.Errorf("no panic for odd wrapped columns")
+		}
+	}()
+	NewWrappedGrid(5, 5, 1, WrapHorizontal, nil, nil, nil)
+}
+
+func TestWrappedGridSeam(T *testing.T) {
+	for _, h := range []*Grid{
+		NewWrappedGrid(6, 5, 1, WrapHorizontal, nil, nil, nil),
+		NewCompactWrappedGrid(6, 5, 1, WrapHorizontal, nil, nil, nil),
+	} {
+		var (
+			east = hexcoords.Hex{h.ColMax(), 0}
+			west = hexcoords.Hex{h.ColMin(), 0}
+			over = east.Adjacent(hex.NE)
+		)
+		if c, ok := h.Wrap(over); !ok || c != west {
+			T.Fatalf("compact=%v: %v wraps to %v", h.IsCompact(), over, c)
+		}
+		var found bool
+		for _, adj := range h.Adjacents(east) {
+			found = found || adj == west
+		}
+		if !found || len(h.Adjacents(east)) != 6 {
+			T.Errorf("compact=%v: adjacents %v", h.IsCompact(), h.Adjacents(east))
+		}
+		if h.Distance(east, west) != 1 {
+			T.Errorf("compact=%v: distance across seam %d", h.IsCompact(), h.Distance(east, west))
+		}
+
+		h.SetTileValue(over, "x")
+		if h.TileValue(west) != "x" {
+			T.Errorf("compact=%v: tile value not shared across seam", h.IsCompact())
+		}
+		var (
+			seamEdge = east.Edges(hex.NE)[0]
+			same     = west.Edges(hex.SW)[0]
+		)
+		if !h.EdgesIdentical(seamEdge, same) {
+			T.Errorf("compact=%v: %v and %v not identical", h.IsCompact(), seamEdge, same)
+		}
+		h.SetEdgeValue(seamEdge, 1)
+		if h.EdgeValue(same) != 1 {
+			T.Errorf("compact=%v: edge value not shared across seam", h.IsCompact())
+		}
+		var vc = hexcoords.Vertex{east.U, east.V, 2}
+		for _, ident := range vc.IdenticalVertices() {
+			if !h.VerticesIdentical(vc, ident) {
+				T.Errorf("compact=%v: %v and %v not identical", h.IsCompact(), vc, ident)
+			}
+		}
+		h.SetVertexValue(vc, 2)
+		for _, ident := range vc.IdenticalVertices() {
+			if h.VertexValue(ident) != 2 {
+				T.Errorf("compact=%v: vertex %v value not shared", h.IsCompact(), ident)
+			}
+		}
+
+		var edges, vertices = h.GetEdges(over), h.GetVertices(over)
+		if edges == nil || vertices == nil {
+			T.Fatalf("compact=%v: no edges or vertices for %v", h.IsCompact(), over)
+		}
+		for k, e := range h.GetEdges(west) {
+			if *edges[k] != *e {
+				T.Errorf("compact=%v: edge %d of %v is %v, expected %v", h.IsCompact(), k, over, *edges[k], *e)
+			}
+		}
+		for k, v := range h.GetVertices(west) {
+			if *vertices[k] != *v {
+				T.Errorf("compact=%v: vertex %d of %v is %v, expected %v", h.IsCompact(), k, over, *vertices[k], *v)
+			}
+		}
+		if p := h.GetHex(over); p == nil || *p != *h.GetHex(west) {
+			T.Errorf("compact=%v: hexagon of %v is %v", h.IsCompact(), over, p)
+		}
+		for k := 0; k < 6; k++ {
+			var (
+				vc = hexcoords.Vertex{over.U, over.V, k}
+				p  = h.GetVertexPoint(vc)
+			)
+			if p != h.GetVertexPoint(hexcoords.Vertex{west.U, west.V, k}) || h.GetVertex(vc) == nil {
+				T.Errorf("compact=%v: vertex %v at %v", h.IsCompact(), vc, p)
+			}
+		}
+	}
+}
+
+func TestWrappedFloodFill(T *testing.T) {
+	var h = NewWrappedGrid(6, 5, 1, WrapBoth, 0, nil, nil)
+	for _, u := range []int{h.ColMin(), h.ColMax()} {
+		for v := h.RowMin(); v <= h.RowMax(); v++ {
+			h.SetTileValue(hexcoords.Hex{u, v}, 1)
+		}
+	}
+	var components = h.Components(func(t *Tile) bool { return t.Value == 1 })
+	if len(components) != 1 || len(components[0]) != 10 {
+		T.Errorf("components across seam %v", components)
+	}
+	var band = components[0]
+	if b := h.Boundary(band); len(b) != 20 {
+		T.Errorf("%d boundary edges of wrapped band", len(b))
+	}
+}
+
+func TestShortestPath(T *testing.T) {
+	var (
+		h     = NewGrid(7, 7, 1, 0, nil, nil)
+		from  = hexcoords.Hex{-2, 0}
+		to    = hexcoords.Hex{2, 0}
+		walls = []hexcoords.Hex{{0, -3}, {0, -2}, {0, -1}, {0, 0}, {0, 1}, {0, 2}}
+	)
+	var path, cost, ok = h.ShortestPath(from, to, nil)
+	if !ok || len(path) != 5 || cost != 4 || path[0] != from || path[4] != to {
+		T.Fatalf("open path %v cost %v", path, cost)
+	}
+	for _, c := range walls {
+		h.SetTileValue(c, 1)
+	}
+	var blocked = StepCost(func(a, b hexcoords.Hex) float64 {
+		if h.TileValue(b) == 1 {
+			return -1
+		}
+		return 1
+	})
+	path, cost, ok = h.ShortestPath(from, to, blocked)
+	if !ok || path[len(path)-1] != to {
+		T.Fatalf("no path around wall")
+	}
+	for i := 1; i < len(path); i++ {
+		if h.TileValue(path[i]) == 1 || h.Distance(path[i-1], path[i]) != 1 {
+			T.Errorf("invalid step %v -> %v", path[i-1], path[i])
+		}
+	}
+	if int(cost) != len(path)-1 || cost <= 4 {
+		T.Errorf("path cost %v, length %d", cost, len(path))
+	}
+	h.SetTileValue(hexcoords.Hex{0, 3}, 1)
+	if _, _, ok = h.ShortestPath(from, to, blocked); ok {
+		T.Errorf("path through complete wall")
+	}
+
+	var wrapped = NewWrappedGrid(8, 5, 1, WrapHorizontal, nil, nil, nil)
+	path, _, _ = wrapped.ShortestPath(hexcoords.Hex{wrapped.ColMin(), 0}, hexcoords.Hex{wrapped.ColMax(), 0}, nil)
+	if len(path) != 2 {
+		T.Errorf("wrapped path %v", path)
+	}
+}